@@ -5,13 +5,67 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"olexsmir.xyz/json2go"
 )
 
+var nullStyles = map[string]json2go.NullStyle{
+	"pointer":  json2go.NullPointer,
+	"sql":      json2go.NullSQL,
+	"optional": json2go.NullOptional,
+}
+
+var bigIntStyles = map[string]json2go.BigIntStyle{
+	"number": json2go.BigIntNumber,
+	"uint64": json2go.BigIntUint64,
+	"big":    json2go.BigIntBig,
+}
+
+var omitStyles = map[string]json2go.OmitStyle{
+	"none":  json2go.OmitNone,
+	"empty": json2go.OmitEmpty,
+	"zero":  json2go.OmitZero,
+}
+
 func main() {
 	typeName := flag.String("type", "AutoGenerated", "a name for generated type")
+	var outputFormat string
+	flag.StringVar(&outputFormat, "format", "go", "output format: go, ts, jsonschema, proto")
+	flag.StringVar(&outputFormat, "lang", "go", "same as -format, e.g. -lang=ts")
+	inputFormat := flag.String("input", "json", "input format: json, jsonschema")
+	readFiles := flag.Bool("files", false, "read samples from json files and directories given as arguments, merged into one type")
+	ndjson := flag.Bool("ndjson", false, "read newline-delimited json, one sample per line, merged into one type")
 	noTags := flag.Bool("no-json-tags", false, "do not include json tags on struct fields")
+	initialisms := flag.String("initialisms", "", "comma separated extra initialisms, e.g. SKU,ETA")
+	pkgName := flag.String("package", "", "generate a complete Go file with this package name")
+	detectMaps := flag.Bool("maps", false, "detect objects with dynamic keys and type them as maps")
+	mapThreshold := flag.Int("map-threshold", 20, "number of alike fields that makes -maps type an object as a map")
+	var mapPaths []string
+	flag.Func("map-path", "JSON path of an object always typed as a map, e.g. $.users, can be repeated", func(path string) error {
+		mapPaths = append(mapPaths, path)
+		return nil
+	})
+	namedTypes := flag.Bool("named-types", false, "declare nested objects as separate named types")
+	tags := flag.String("tags", "json", "comma separated struct tag keys")
+	numberType := flag.String("number-type", "", "Go type for all numbers, e.g. encoding/json.Number")
+	sizedInts := flag.Bool("sized-ints", false, "use int64 for integers outside the int32 range")
+	unsignedInts := flag.Bool("unsigned", false, "use uint64 for integers that are never negative")
+	bigInts := flag.String("big-ints", "number", "type for integers overflowing int64: number, uint64, big")
+	detectQuoted := flag.Bool("quoted", false, "detect numbers and booleans encoded as strings")
+	detectTime := flag.Bool("time", false, "detect time.Time from RFC 3339 strings")
+	var timeLayouts []string
+	flag.Func("time-layout", "layout of strings with the \"date\" format, typed with -formats, can be repeated", func(layout string) error {
+		timeLayouts = append(timeLayouts, layout)
+		return nil
+	})
+	formatsFile := flag.String("formats", "", "json file mapping string formats to Go types")
+	omitStyle := flag.String("omit", "none", "json tag option for optional fields: none, empty, zero (default empty with -files and -ndjson)")
+	nullStyle := flag.String("null", "pointer", "type for sometimes null values: pointer, sql, optional")
+	unions := flag.Bool("unions", false, "type objects told apart by a discriminator field as unions")
+	discriminators := flag.String("discriminators", "", "comma separated discriminator keys for -unions (default: type,kind,__typename)")
 	showHelp := flag.Bool("help", false, "show help")
 	flag.Parse()
 
@@ -20,6 +74,43 @@ func main() {
 		os.Exit(0)
 	}
 
+	nulls, ok := nullStyles[*nullStyle]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown null style: %q\n", *nullStyle)
+		os.Exit(1)
+	}
+
+	bigIntStyle, ok := bigIntStyles[*bigInts]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown big int style: %q\n", *bigInts)
+		os.Exit(1)
+	}
+
+	// samples are merged to find optional fields, so mark them unless told otherwise
+	if *readFiles || *ndjson {
+		omitSet := false
+		flag.Visit(func(f *flag.Flag) { omitSet = omitSet || f.Name == "omit" })
+		if !omitSet {
+			*omitStyle = "empty"
+		}
+	}
+
+	omit, ok := omitStyles[*omitStyle]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown omit style: %q\n", *omitStyle)
+		os.Exit(1)
+	}
+
+	var stringFormats map[string]string
+	if *formatsFile != "" {
+		var ferr error
+		stringFormats, ferr = loadStringFormats(*formatsFile)
+		if ferr != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load string formats: %v\n", ferr)
+			os.Exit(1)
+		}
+	}
+
 	// get input
 	args := flag.Args()
 
@@ -32,7 +123,33 @@ func main() {
 	isPiped := (stat.Mode() & os.ModeCharDevice) == 0
 
 	var input string
+	var samples []string      // set when several samples are merged
+	var ndjsonInput io.Reader // set for -ndjson, read as it is merged
 	switch {
+	case *ndjson && *readFiles:
+		files, ferr := listFiles(args, ".jsonl", ".ndjson")
+		if ferr == nil {
+			ndjsonInput, ferr = openLines(files)
+		}
+		if ferr != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read samples: %v\n", ferr)
+			os.Exit(1)
+		}
+	case *ndjson && len(args) > 0:
+		ndjsonInput = strings.NewReader(args[0])
+	case *ndjson && isPiped:
+		ndjsonInput = os.Stdin
+	case *readFiles:
+		files, ferr := readSamples(args)
+		if ferr != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read samples: %v\n", ferr)
+			os.Exit(1)
+		}
+		if len(files) == 1 {
+			input = files[0]
+		} else {
+			samples = files
+		}
 	case len(args) > 0:
 		input = args[0]
 	case isPiped:
@@ -47,7 +164,78 @@ func main() {
 		os.Exit(1)
 	}
 
-	type_, err := json2go.Transform(*typeName, input, !*noTags)
+	var extraInitialisms []string
+	if *initialisms != "" {
+		extraInitialisms = strings.Split(*initialisms, ",")
+	}
+
+	var discriminatorKeys []string
+	if *discriminators != "" {
+		discriminatorKeys = strings.Split(*discriminators, ",")
+	}
+
+	opts := json2go.Options{
+		OmitTags:       *noTags,
+		Tags:           strings.Split(*tags, ","),
+		OmitStyle:      omit,
+		Initialisms:    extraInitialisms,
+		PackageName:    *pkgName,
+		DetectMaps:     *detectMaps,
+		MapThreshold:   *mapThreshold,
+		MapPaths:       mapPaths,
+		NamedTypes:     *namedTypes,
+		NumberType:     *numberType,
+		SizedInts:      *sizedInts,
+		UnsignedInts:   *unsignedInts,
+		BigIntStyle:    bigIntStyle,
+		DetectQuoted:   *detectQuoted,
+		DetectTime:     *detectTime,
+		TimeLayouts:    timeLayouts,
+		StringFormats:  stringFormats,
+		NullStyle:      nulls,
+		Unions:         *unions,
+		Discriminators: discriminatorKeys,
+	}
+
+	switch *inputFormat {
+	case "json":
+	case "jsonschema":
+		if outputFormat != "go" {
+			fmt.Fprintf(os.Stderr, "Input format jsonschema can only be converted to go, not %q\n", outputFormat)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown input format: %q\n", *inputFormat)
+		os.Exit(1)
+	}
+	if (samples != nil || ndjsonInput != nil) && (outputFormat != "go" || *inputFormat != "json") {
+		fmt.Fprintf(os.Stderr, "Several samples can only be merged from json to go\n")
+		os.Exit(1)
+	}
+
+	var type_ string
+	switch outputFormat {
+	case "go":
+		switch {
+		case samples != nil:
+			type_, err = json2go.TransformSamples(*typeName, samples, opts)
+		case ndjsonInput != nil:
+			type_, err = json2go.TransformNDJSON(*typeName, ndjsonInput, opts)
+		case *inputFormat == "jsonschema":
+			type_, err = json2go.TransformSchema(*typeName, input, opts)
+		default:
+			type_, err = json2go.TransformWithOptions(*typeName, input, opts)
+		}
+	case "ts":
+		type_, err = json2go.TransformToTypeScript(*typeName, input, opts)
+	case "jsonschema":
+		type_, err = json2go.TransformToSchema(*typeName, input, opts)
+	case "proto":
+		type_, err = json2go.TransformToProto(*typeName, input, opts)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown output format: %q\n", outputFormat)
+		os.Exit(1)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to transform json to type annotation: %v\n", err)
 		os.Exit(1)
@@ -56,6 +244,96 @@ func main() {
 	fmt.Println(type_)
 }
 
+// readSamples reads the json files at paths, and the .json files
+// in the directories among them, in the order they are listed.
+func readSamples(paths []string) ([]string, error) {
+	files, err := listFiles(paths, ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]string, len(files))
+	for i, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		samples[i] = string(data)
+	}
+	return samples, nil
+}
+
+// listFiles returns the files at paths, replacing directories
+// with the files in them that have one of the extensions.
+func listFiles(paths []string, exts ...string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		stat, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !stat.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.Type().IsRegular() && slices.Contains(exts, filepath.Ext(entry.Name())) {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no %s files found", strings.Join(exts, ", "))
+	}
+	return files, nil
+}
+
+// openLines opens files as a single stream of lines, with a line break
+// after each file in case its last line has none. The files are left open
+// until the program exits.
+func openLines(files []string) (io.Reader, error) {
+	readers := make([]io.Reader, 0, 2*len(files))
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		readers = append(readers, f, strings.NewReader("\n"))
+	}
+	return io.MultiReader(readers...), nil
+}
+
+// loadStringFormats reads a json object mapping string formats to Go types,
+// e.g. {"uuid": "github.com/google/uuid.UUID"}.
+func loadStringFormats(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := json2go.NewParser(json2go.NewLexer(data)).Parse()
+	if err != nil {
+		return nil, err
+	}
+	if v.Kind != json2go.ObjectValue {
+		return nil, fmt.Errorf("expected an object of format to type")
+	}
+
+	formats := make(map[string]string, len(v.Object))
+	for _, f := range v.Object {
+		if f.V.Kind != json2go.StringValue {
+			return nil, fmt.Errorf("type of format %q is not a string", f.K)
+		}
+		formats[f.K] = f.V.Str
+	}
+	return formats, nil
+}
+
 func printHelp() {
 	fmt.Println(`
 Convert json to Go type annotations.
@@ -65,8 +343,61 @@ Usage:
 	echo '{"json": "here"}' | json2go -type=MyTypeName
 	json2go -type=MyTypeName '{"json": "here"}'
 	json2go -no-json-tags '{"json": "here"}'
+	json2go -tags=json,yaml '{"json": "here"}'
+	json2go -initialisms=SKU '{"item_sku": "here"}'
+	json2go -named-types '{"user": {"name": "here"}}'
+	json2go -maps '{"users": {"1234": {"name": "here"}, "5678": {"name": "there"}}}'
+	json2go -map-path='$.labels' '{"labels": {"env": "prod", "team": "core"}}'
+	json2go -package=models -named-types '{"user": {"name": "here"}}'
+	json2go -null=sql '[{"age": 1}, {"age": null}]'
+	json2go -number-type=github.com/shopspring/decimal.Decimal '{"price": 9.99}'
+	json2go -sized-ints -unsigned '{"id": 18446744073709551, "delta": -1}'
+	json2go -big-ints=big '{"id": 18446744073709551616}'
+	json2go -quoted '{"count": "42", "price": "9.99"}'
+	json2go -time '{"created_at": "2024-05-01T12:00:00Z"}'
+	json2go -formats=formats.json '{"id": "9b2f7c52-3a0e-4a7b-8f43-2c1d5e6f7a8b"}'
+	json2go -omit=zero '[{"id": 1, "age": 1}, {"id": 2}]'
+	json2go -unions '[{"type": "click", "x": 1}, {"type": "view", "page": "/"}]'
+	json2go -format=jsonschema -type=User '{"id": 1, "name": "here"}'
+	json2go -lang=ts -type=User '{"id": 1, "name": "here"}'
+	json2go -format=proto -package=api.v1 -type=User '{"id": 1, "created_at": "2024-05-01T12:00:00Z"}'
+	json2go -files -type=User user1.json user2.json samples/
+	cat events.jsonl | json2go -ndjson -type=Event
+	json2go -input=jsonschema -type=User '{"type": "object", "properties": {"id": {"type": "integer"}}}'
 
 Flags:
-	-type=NAME         Type name for root type (default: AutoGenerated)
-	-no-json-tags      Omit json struct tags`[1:])
+	-type=NAME         Type name for root type, or the schema title (default: AutoGenerated)
+	-format=FORMAT     Output format: go, ts (TypeScript), jsonschema (draft 2020-12), proto (proto3) (default: go)
+	-lang=LANG         Same as -format, e.g. -lang=ts
+	-input=FORMAT      Input format: json, jsonschema (a JSON Schema document, go output only) (default: json)
+	-ndjson            Read newline-delimited json (JSON Lines), one sample per line, merged into one type;
+	                   from stdin, the argument, or with -files the files and directories (their .jsonl
+	                   and .ndjson files) given as arguments, streamed line by line (-omit default: empty)
+	-files             Read samples from the json files and directories (their .json files) given as
+	                   arguments, merged into one type with fields missing in some samples optional
+	                   (-omit default: empty)
+	-no-json-tags      Omit struct tags
+	-tags=KEYS         Comma separated struct tag keys (default: json)
+	-initialisms=WORDS Comma separated extra initialisms kept in all caps, e.g. SKU,ETA
+	-package=NAME      Generate a complete Go file with the package clause and imports,
+	                   or the package of a proto file
+	-maps              Type objects with dynamic keys (IDs, dates, hashes, many alike fields) as maps
+	-map-threshold=N   Number of alike fields that makes -maps type an object as a map (default: 20)
+	-map-path=PATH     JSON path of an object always typed as a map, e.g. $.items[*].labels, can be repeated
+	-named-types       Declare nested objects as separate named types
+	-number-type=TYPE  Go type for all numbers, e.g. encoding/json.Number, github.com/shopspring/decimal.Decimal
+	-sized-ints        Use int64 for integers outside the int32 range
+	-unsigned          Use uint64 for integers that are never negative
+	-big-ints=STYLE    Type for integers overflowing int64: number (json.Number), uint64, big (*big.Int) (default: number)
+	-quoted            Detect numbers and booleans encoded as strings, using the ,string tag option
+	-time              Detect time.Time from RFC 3339 strings
+	-time-layout=L     Layout of strings with the "date" format, typed only if -formats maps it,
+	                   can be repeated (default: 2006-01-02)
+	-formats=FILE      Json file mapping string formats to Go types, e.g.
+	                   {"uuid": "github.com/google/uuid.UUID", "ipv4": "net/netip.Addr"}
+	                   formats: date-time date uuid ipv4 ipv6 email uri duration byte
+	-omit=STYLE        Json tag option for fields missing in some samples: none, empty, zero (default: none, empty with -files and -ndjson)
+	-null=STYLE        Type for values null in some samples: pointer, sql, optional (default: pointer)
+	-unions            Type objects told apart by a discriminator field as a union of variant structs
+	-discriminators=K  Comma separated discriminator keys for -unions (default: type,kind,__typename)`[1:])
 }
@@ -0,0 +1,74 @@
+package json2go
+
+import "strconv"
+
+// namedShape is a shape waiting to be written as a named type.
+type namedShape struct {
+	name  string
+	shape *shape
+	union string // union the type is a variant of, if any
+}
+
+// declarations tracks the named types of a generated file, shared by the backends.
+type declarations struct {
+	queue    []namedShape      // types waiting to be written, writing one may queue more
+	declared map[string]string // shape signature -> declared type name
+	names    map[string]bool   // names of all declared types
+	named    map[*shape]string // named shape -> declared type name, see [shape.target]
+	ranges   bool              // integer ranges change the declared types, see [shape.signature]
+}
+
+// newDeclarations returns declarations where only the root type name is taken.
+func newDeclarations(root string, ranges bool) declarations {
+	return declarations{
+		ranges:   ranges,
+		declared: make(map[string]string),
+		names:    map[string]bool{root: true},
+		named:    make(map[*shape]string),
+	}
+}
+
+// declare queues s to be declared as a named type and returns its name.
+// If a type with the same fields was already declared, that name is reused.
+// If a different type already took the name, a numeric suffix is added.
+func (d *declarations) declare(name string, s *shape) string {
+	shared := len(s.fields) > 0 || len(s.enum) > 0 // empty objects are placeholders, never shared
+	outer := *s
+	outer.null = false // nullable uses are written as pointers, the declared type is the same
+	sig := outer.signature(d.ranges)
+	if existing, ok := d.declared[sig]; ok && shared {
+		return existing
+	}
+
+	name = d.unique(name)
+	if shared {
+		d.declared[sig] = name
+	}
+
+	d.queue = append(d.queue, namedShape{name: name, shape: s})
+	return name
+}
+
+// declareNamed queues a named shape to be declared under its own name,
+// once, and returns the name.
+func (d *declarations) declareNamed(s *shape) string {
+	if name, ok := d.named[s]; ok {
+		return name
+	}
+
+	name := d.unique(s.name)
+	d.named[s] = name
+	d.queue = append(d.queue, namedShape{name: name, shape: s})
+	return name
+}
+
+// unique reserves name for a declared type, adding a numeric suffix
+// if a different type already took it.
+func (d *declarations) unique(name string) string {
+	unique := name
+	for n := 2; d.names[unique]; n++ {
+		unique = name + strconv.Itoa(n)
+	}
+	d.names[unique] = true
+	return unique
+}
@@ -0,0 +1,165 @@
+package json2go
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// writeJSON writes v as json indented by two spaces per level,
+// starting at the given nesting depth. Strings and keys are written
+// as they are, already escaped.
+func writeJSON(buf *strings.Builder, v Value, depth int) {
+	switch v.Kind {
+	case NullValue:
+		buf.WriteString("null")
+	case BoolValue:
+		buf.WriteString(strconv.FormatBool(v.Bool))
+	case StringValue:
+		buf.WriteByte('"')
+		buf.WriteString(v.Str)
+		buf.WriteByte('"')
+	case NumberValue:
+		if v.Raw != "" {
+			buf.WriteString(v.Raw)
+		} else {
+			buf.WriteString(strconv.FormatInt(v.Int, 10))
+		}
+	case DecimalValue:
+		buf.WriteString(strconv.FormatFloat(v.Float, 'g', -1, 64))
+
+	case ObjectValue:
+		if len(v.Object) == 0 {
+			buf.WriteString("{}")
+			return
+		}
+		buf.WriteString("{\n")
+		for i, f := range v.Object {
+			if i > 0 {
+				buf.WriteString(",\n")
+			}
+			writeJSONIndent(buf, depth+1)
+			buf.WriteByte('"')
+			buf.WriteString(f.K)
+			buf.WriteByte('"')
+			buf.WriteString(": ")
+			writeJSON(buf, f.V, depth+1)
+		}
+		buf.WriteByte('\n')
+		writeJSONIndent(buf, depth)
+		buf.WriteByte('}')
+
+	case ArrayValue:
+		if len(v.Array) == 0 {
+			buf.WriteString("[]")
+			return
+		}
+		buf.WriteString("[\n")
+		for i, item := range v.Array {
+			if i > 0 {
+				buf.WriteString(",\n")
+			}
+			writeJSONIndent(buf, depth+1)
+			writeJSON(buf, item, depth+1)
+		}
+		buf.WriteByte('\n')
+		writeJSONIndent(buf, depth)
+		buf.WriteByte(']')
+	}
+}
+
+func writeJSONIndent(buf *strings.Builder, depth int) {
+	for i := 0; i < depth; i++ {
+		buf.WriteString("  ")
+	}
+}
+
+// escapeJSON escapes s to be written between quotes as a json string,
+// the form in which the [Parser] keeps strings. Invalid UTF-8 is
+// replaced by U+FFFD, like encoding/json does.
+func escapeJSON(s string) string {
+	const hex = "0123456789abcdef"
+
+	var buf strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '"', r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r == '\n':
+			buf.WriteString(`\n`)
+		case r == '\r':
+			buf.WriteString(`\r`)
+		case r == '\t':
+			buf.WriteString(`\t`)
+		case r < 0x20:
+			buf.WriteString(`\u00`)
+			buf.WriteByte(hex[r>>4])
+			buf.WriteByte(hex[r&0xf])
+		default:
+			buf.WriteRune(r) // ranging yields U+FFFD for invalid bytes
+		}
+	}
+	return buf.String()
+}
+
+// unescapeJSON decodes the escape sequences of a json string as the [Parser]
+// keeps it, which were already validated by the [Lexer]. Surrogate pairs are
+// combined, and unpaired surrogates replaced by U+FFFD, like encoding/json does.
+func unescapeJSON(raw string) string {
+	if !strings.Contains(raw, `\`) {
+		return raw
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '\\' || i+1 >= len(raw) {
+			buf.WriteByte(raw[i])
+			continue
+		}
+
+		i++
+		switch raw[i] {
+		case 'b':
+			buf.WriteByte('\b')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 't':
+			buf.WriteByte('\t')
+		case 'u':
+			r, ok := hexRune(raw[i+1:])
+			if !ok {
+				buf.WriteString(`\u`) // not produced by the Lexer
+				continue
+			}
+			i += 4
+			if utf16.IsSurrogate(r) {
+				r2, ok := rune(-1), false
+				if strings.HasPrefix(raw[i+1:], `\u`) {
+					r2, ok = hexRune(raw[i+3:])
+				}
+				if r = utf16.DecodeRune(r, r2); ok && r != utf8.RuneError {
+					i += 6
+				}
+			}
+			buf.WriteRune(r)
+		default: // '"', '\\' and '/'
+			buf.WriteByte(raw[i])
+		}
+	}
+	return buf.String()
+}
+
+// hexRune parses the 4 hex digits at the start of s.
+func hexRune(s string) (rune, bool) {
+	if len(s) < 4 {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(s[:4], 16, 32)
+	return rune(n), err == nil
+}
@@ -0,0 +1,71 @@
+package json2go
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteJSON(t *testing.T) {
+	tests := map[string]string{
+		`{}`:                       `{}`,
+		`[]`:                       `[]`,
+		`[1, -2.5, true, null]`:    "[\n  1,\n  -2.5,\n  true,\n  null\n]",
+		`{"a": {"b": [1]}}`:        "{\n  \"a\": {\n    \"b\": [\n      1\n    ]\n  }\n}",
+		`"quote \" and \\ slash"`:  `"quote \" and \\ slash"`,
+		`"tab\t new\n bell\u0007"`: `"tab\t new\n bell\u0007"`,
+		`{"esc\"aped": "\u00e9"}`:  "{\n  \"esc\\\"aped\": \"\\u00e9\"\n}",
+		`"unicode ✓ é"`:            `"unicode ✓ é"`,
+		`18446744073709551616`:     `18446744073709551616`,
+	}
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			v, err := NewParser(NewLexer([]byte(input))).Parse()
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			var buf strings.Builder
+			writeJSON(&buf, v, 0)
+			if got := buf.String(); got != expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+			}
+		})
+	}
+}
+
+func TestEscapeJSON(t *testing.T) {
+	tests := map[string]string{
+		"plain":                "plain",
+		`quote " \ slash`:      `quote \" \\ slash`,
+		"tab\t new\n\r bell\a": `tab\t new\n\r bell\u0007`,
+		"unicode ✓ é":          "unicode ✓ é",
+		"invalid \xff":         "invalid \ufffd",
+	}
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			if got := escapeJSON(input); got != expected {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+		})
+	}
+}
+
+func TestUnescapeJSON(t *testing.T) {
+	tests := map[string]string{
+		`plain`:                 "plain",
+		`quote \" \\ \/ slash`:  `quote " \ / slash`,
+		`tab\t new\n\r \b\f`:    "tab\t new\n\r \b\f",
+		`\u00e9 \u2713`:         "é ✓",
+		`\ud83d\ude00 smile`:    "😀 smile",
+		`lone \ud83d high`:      "lone \ufffd high",
+		`lone \ude00 low`:       "lone \ufffd low",
+		`\ud83d\u0041 unpaired`: "\ufffdA unpaired",
+	}
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			if got := unescapeJSON(input); got != expected {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+		})
+	}
+}
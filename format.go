@@ -0,0 +1,143 @@
+package json2go
+
+import (
+	"encoding/base64"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// builtinFormats are the string formats detected when they are mapped in
+// [Options.StringFormats], in the order they are tried.
+// The names follow the JSON Schema "format" vocabulary where one exists.
+var builtinFormats = []struct {
+	name  string
+	match func(s string) bool
+}{
+	{"uuid", isUUID},
+	{"ipv4", isIPv4},
+	{"ipv6", isIPv6},
+	{"email", isEmail},
+	{"uri", isURI},
+	{"duration", isDuration},
+	{"byte", isBase64},
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !unicode.Is(unicode.ASCII_Hex_Digit, r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isIPv4(s string) bool {
+	ip, err := netip.ParseAddr(s)
+	return err == nil && ip.Is4()
+}
+
+func isIPv6(s string) bool {
+	ip, err := netip.ParseAddr(s)
+	return err == nil && ip.Is6()
+}
+
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+func isURI(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// isDuration matches [time.ParseDuration] strings with a unit, like "1h30m".
+func isDuration(s string) bool {
+	_, err := time.ParseDuration(s)
+	return err == nil && strings.ContainsFunc(s, unicode.IsLetter)
+}
+
+// isBase64 matches padded standard base64 strings. Short strings are
+// ignored, since most words are also valid base64.
+func isBase64(s string) bool {
+	if len(s) < 16 || len(s)%4 != 0 {
+		return false
+	}
+	_, err := base64.StdEncoding.DecodeString(s)
+	return err == nil
+}
+
+// quotedTypes are the types of strings holding a json number or boolean,
+// by the format reported by [quotedFormat].
+var quotedTypes = map[string]string{
+	"quoted-int":   "int64",
+	"quoted-float": "float64",
+	"quoted-bool":  "bool",
+}
+
+// quotedFormat classifies strings holding a json number or boolean, which
+// encoding/json decodes into numeric and bool fields with the ,string tag option.
+// Integers that overflow int64 are left as plain strings.
+func quotedFormat(s string) string {
+	if s == "true" || s == "false" {
+		return "quoted-bool"
+	}
+
+	lexer := NewLexer([]byte(s))
+	tok := lexer.Next()
+	if lexer.Next().Type != EOF {
+		return ""
+	}
+
+	switch tok.Type {
+	case NUMBER:
+		if _, err := strconv.ParseInt(tok.Literal, 10, 64); err == nil {
+			return "quoted-int"
+		}
+	case DECIMAL:
+		return "quoted-float"
+	}
+	return ""
+}
+
+// goType is a Go type used in the generated code.
+type goType struct {
+	name       string // as written in code, e.g. "*url.URL"
+	importPath string // e.g. "net/url", empty for predeclared types
+}
+
+// parseGoType parses a type spec of an optional import path followed
+// by the type name, e.g. "github.com/google/uuid.UUID", "*net/url.URL",
+// "time.Duration" or "[]byte". The package name is assumed to be
+// the last element of the import path.
+func parseGoType(spec string) goType {
+	typ := strings.TrimLeft(spec, "*[]")
+	prefix := spec[:len(spec)-len(typ)]
+
+	slash := strings.LastIndexByte(typ, '/')
+	dot := strings.LastIndexByte(typ, '.')
+	if dot <= slash {
+		return goType{name: spec}
+	}
+
+	path := typ[:dot]
+	return goType{
+		name:       prefix + path[slash+1:] + typ[dot:],
+		importPath: path,
+	}
+}
@@ -0,0 +1,82 @@
+package json2go
+
+import "testing"
+
+func TestBuiltinFormats(t *testing.T) {
+	tests := map[string]string{
+		"9b2f7c52-3a0e-4a7b-8f43-2c1d5e6f7a8b": "uuid",
+		"192.168.0.1":                          "ipv4",
+		"2001:db8::1":                          "ipv6",
+		"alice@example.com":                    "email",
+		"https://example.com/a?b=c":            "uri",
+		"1h30m":                                "duration",
+		"aGVsbG8gd29ybGQgaGVsbG8=":             "byte",
+		"hello world":                          "",
+		"12:30":                                "",
+		"42":                                   "",
+		"abcd":                                 "",
+		"Alice <alice@example.com>":            "",
+	}
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			var got string
+			for _, f := range builtinFormats {
+				if f.match(input) {
+					got = f.name
+					break
+				}
+			}
+			if got != expected {
+				t.Errorf("expected format %q, got %q", expected, got)
+			}
+		})
+	}
+}
+
+func TestParseGoType(t *testing.T) {
+	tests := map[string]goType{
+		"string":                      {name: "string"},
+		"[]byte":                      {name: "[]byte"},
+		"time.Time":                   {name: "time.Time", importPath: "time"},
+		"net/netip.Addr":              {name: "netip.Addr", importPath: "net/netip"},
+		"*net/url.URL":                {name: "*url.URL", importPath: "net/url"},
+		"github.com/google/uuid.UUID": {name: "uuid.UUID", importPath: "github.com/google/uuid"},
+		"[]github.com/google/uuid.UUID": {
+			name:       "[]uuid.UUID",
+			importPath: "github.com/google/uuid",
+		},
+	}
+	for spec, expected := range tests {
+		t.Run(spec, func(t *testing.T) {
+			if got := parseGoType(spec); got != expected {
+				t.Errorf("expected %+v, got %+v", expected, got)
+			}
+		})
+	}
+}
+
+func TestQuotedFormat(t *testing.T) {
+	tests := map[string]string{
+		"42":                   "quoted-int",
+		"-7":                   "quoted-int",
+		"9.99":                 "quoted-float",
+		"1e5":                  "quoted-float",
+		"true":                 "quoted-bool",
+		"false":                "quoted-bool",
+		"18446744073709551616": "",
+		"NaN":                  "",
+		"0x10":                 "",
+		"+1":                   "",
+		" 1":                   "",
+		"1 2":                  "",
+		"True":                 "",
+		"":                     "",
+	}
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			if got := quotedFormat(input); got != expected {
+				t.Errorf("quotedFormat(%q): expected %q, got %q", input, expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,442 @@
+package json2go
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// schemaReader converts a JSON Schema document into the shape of the values
+// it describes, so that it is written by the same [Transpiler] as samples.
+//
+// Object and string enum schemas referenced with $ref become named shapes,
+// shared by every reference to them, which keeps recursive schemas finite.
+type schemaReader struct {
+	doc         Value
+	initialisms map[string]bool
+	targets     map[string]*shape // $ref -> named shape
+	names       map[string]bool   // names of the named shapes
+	resolving   map[string]bool   // $refs of unnamed schemas being converted
+}
+
+// readSchema returns the shape of values described by the schema doc,
+// named rootName when the document refers to itself.
+func readSchema(doc Value, rootName string, initialisms map[string]bool) (*shape, error) {
+	r := &schemaReader{
+		doc:         doc,
+		initialisms: initialisms,
+		targets:     make(map[string]*shape),
+		names:       map[string]bool{rootName: true},
+		resolving:   make(map[string]bool),
+	}
+
+	if _, ok := lookup(doc, "oneOf"); ok {
+		return r.convert(doc)
+	}
+	if _, ok := lookup(doc, "anyOf"); ok {
+		return r.convert(doc)
+	}
+
+	root := &shape{name: rootName}
+	r.targets["#"] = root
+	if ref, ok := lookup(doc, "$ref"); ok { // the root type is the referenced one
+		schema, ok := r.resolve(ref.Str)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot resolve $ref %q", ErrInvalidSchema, ref.Str)
+		}
+		r.targets[ref.Str] = root
+		doc = schema
+	}
+	if err := r.fill(root, doc); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// convert returns the shape of values described by schema.
+func (r *schemaReader) convert(schema Value) (*shape, error) {
+	if schema.Kind == BoolValue { // true and false accept anything and nothing
+		return &shape{}, nil
+	}
+	if schema.Kind != ObjectValue {
+		return nil, fmt.Errorf("%w: schema is not an object", ErrInvalidSchema)
+	}
+
+	if ref, ok := lookup(schema, "$ref"); ok {
+		return r.ref(ref.Str)
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if alts, ok := lookup(schema, key); ok && alts.Kind == ArrayValue {
+			return r.oneOf(alts.Array)
+		}
+	}
+
+	s := &shape{}
+	if err := r.fill(s, schema); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// fill sets the kinds of s described by schema, before converting any
+// nested schemas that may refer back to s.
+func (r *schemaReader) fill(s *shape, schema Value) error {
+	if schema.Kind != ObjectValue {
+		return fmt.Errorf("%w: schema is not an object", ErrInvalidSchema)
+	}
+
+	if nullable, _ := lookup(schema, "nullable"); nullable.Bool { // OpenAPI 3.0
+		s.null = true
+	}
+
+	if values, ok := lookup(schema, "enum"); ok && values.Kind == ArrayValue {
+		fillEnum(s, values.Array)
+		return nil
+	}
+	if value, ok := lookup(schema, "const"); ok {
+		s.add(value, &inferConfig{})
+		return nil
+	}
+
+	types, err := schemaTypeNames(schema)
+	if err != nil {
+		return err
+	}
+	for _, typ := range types {
+		switch typ {
+		case "null":
+			s.null = true
+		case "boolean":
+			s.kinds |= 1 << BoolValue
+		case "string":
+			s.kinds |= 1 << StringValue
+			s.strings = 1
+			if format, ok := lookup(schema, "format"); ok {
+				s.format = format.Str
+			}
+			if encoding, _ := lookup(schema, "contentEncoding"); encoding.Str == "base64" {
+				s.format = "byte"
+			}
+		case "integer":
+			s.kinds |= 1 << NumberValue
+			fillIntRange(s, schema)
+		case "number":
+			s.kinds |= 1 << DecimalValue
+		case "object":
+			s.kinds |= 1 << ObjectValue
+			s.objects = 1
+		case "array":
+			s.kinds |= 1 << ArrayValue
+		default:
+			return fmt.Errorf("%w: unknown type %q", ErrInvalidSchema, typ)
+		}
+	}
+
+	if s.kinds.has(ObjectValue) {
+		if err := r.fillObject(s, schema); err != nil {
+			return err
+		}
+	}
+	if s.kinds.has(ArrayValue) {
+		s.elem = &shape{}
+		if items, ok := lookup(schema, "items"); ok {
+			if s.elem, err = r.convert(items); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fillEnum types s by the enum values, keeping the allowed strings.
+func fillEnum(s *shape, values []Value) {
+	cfg := &inferConfig{}
+	for _, v := range values {
+		s.add(v, cfg)
+	}
+
+	if s.kinds == 1<<StringValue {
+		for _, v := range values {
+			if v.Kind == StringValue {
+				s.enum = append(s.enum, v.Str)
+			}
+		}
+	}
+}
+
+// fillObject sets the fields of s, required ones present in every object.
+// Objects without properties are maps, of any values unless
+// additionalProperties describes them.
+func (r *schemaReader) fillObject(s *shape, schema Value) error {
+	var required []string
+	if list, ok := lookup(schema, "required"); ok {
+		for _, key := range list.Array {
+			required = append(required, key.Str)
+		}
+	}
+
+	properties, _ := lookup(schema, "properties")
+	for _, p := range properties.Object {
+		fieldShape, err := r.convert(p.V)
+		if err != nil {
+			return err
+		}
+
+		field := s.field(p.K)
+		field.shape = fieldShape
+		for _, key := range required {
+			if key == p.K {
+				field.count = s.objects
+			}
+		}
+	}
+
+	additional, ok := lookup(schema, "additionalProperties")
+	switch {
+	case len(properties.Object) > 0:
+	case !ok || additional.Kind == BoolValue && additional.Bool:
+		s.mapValue = &shape{}
+	case additional.Kind == ObjectValue:
+		var err error
+		if s.mapValue, err = r.convert(additional); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillIntRange sets the range of integers from the bounds of schema,
+// or the int32 format, to the whole int64 range otherwise.
+func fillIntRange(s *shape, schema Value) {
+	s.ints = 1
+	s.minInt, s.maxInt = math.MinInt64, math.MaxInt64
+	if format, _ := lookup(schema, "format"); format.Str == "int32" {
+		s.minInt, s.maxInt = math.MinInt32, math.MaxInt32
+	}
+	if minimum, ok := lookup(schema, "minimum"); ok && minimum.Kind == NumberValue && minimum.Raw == "" {
+		s.minInt = minimum.Int
+	}
+	if maximum, ok := lookup(schema, "maximum"); ok && maximum.Kind == NumberValue && maximum.Raw == "" {
+		s.maxInt = maximum.Int
+	}
+}
+
+// schemaTypeNames returns the types listed by the "type" keyword,
+// or the ones implied by the other keywords if it is missing.
+func schemaTypeNames(schema Value) ([]string, error) {
+	typ, ok := lookup(schema, "type")
+	switch {
+	case ok && typ.Kind == StringValue:
+		return []string{typ.Str}, nil
+	case ok && typ.Kind == ArrayValue:
+		types := make([]string, len(typ.Array))
+		for i, t := range typ.Array {
+			types[i] = t.Str
+		}
+		return types, nil
+	case ok:
+		return nil, fmt.Errorf("%w: type is not a string or an array", ErrInvalidSchema)
+	}
+
+	for _, key := range []string{"properties", "additionalProperties", "required"} {
+		if _, ok := lookup(schema, key); ok {
+			return []string{"object"}, nil
+		}
+	}
+	if _, ok := lookup(schema, "items"); ok {
+		return []string{"array"}, nil
+	}
+	return nil, nil // any value
+}
+
+// ref returns the shape of values described by the schema at ref.
+func (r *schemaReader) ref(ref string) (*shape, error) {
+	if target, ok := r.targets[ref]; ok {
+		return &shape{kinds: target.kinds, null: target.null, target: target}, nil
+	}
+
+	schema, ok := r.resolve(ref)
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot resolve $ref %q", ErrInvalidSchema, ref)
+	}
+
+	if !isNamedSchema(schema) {
+		if r.resolving[ref] {
+			return &shape{}, nil // a cycle with no named type to break it, typed as any
+		}
+		r.resolving[ref] = true
+		defer delete(r.resolving, ref)
+		return r.convert(schema)
+	}
+
+	name := goName(ref[strings.LastIndexByte(ref, '/')+1:], r.initialisms)
+	unique := name
+	for n := 2; r.names[unique]; n++ {
+		unique = name + strconv.Itoa(n)
+	}
+	r.names[unique] = true
+
+	target := &shape{name: unique}
+	r.targets[ref] = target
+	if err := r.fill(target, schema); err != nil {
+		return nil, err
+	}
+	return &shape{kinds: target.kinds, null: target.null, target: target}, nil
+}
+
+// isNamedSchema reports whether a referenced schema is declared as a named
+// type: an object, or a string enum.
+func isNamedSchema(schema Value) bool {
+	if _, ok := lookup(schema, "$ref"); ok {
+		return false
+	}
+	if values, ok := lookup(schema, "enum"); ok {
+		for _, v := range values.Array {
+			if v.Kind != StringValue {
+				return false
+			}
+		}
+		return true
+	}
+	types, _ := schemaTypeNames(schema)
+	return len(types) == 1 && types[0] == "object"
+}
+
+// resolve returns the schema at ref, a JSON pointer into the document like "#/$defs/user".
+func (r *schemaReader) resolve(ref string) (Value, bool) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return Value{}, false // only local references are supported
+	}
+
+	v := r.doc
+	for token := range strings.SplitSeq(pointer, "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch v.Kind {
+		case ObjectValue:
+			if v, ok = lookup(v, token); !ok {
+				return Value{}, false
+			}
+		case ArrayValue:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v.Array) {
+				return Value{}, false
+			}
+			v = v.Array[i]
+		default:
+			return Value{}, false
+		}
+	}
+	return v, true
+}
+
+// oneOf returns the shape of values described by one of the schemas alts.
+//
+// Null alternatives make the others nullable. Objects whose alternatives
+// all fix the same property to a different string are a union discriminated
+// by it, see [Options.Unions], and their fields are also merged in case
+// unions are not generated.
+func (r *schemaReader) oneOf(alts []Value) (*shape, error) {
+	nullable := false
+	var schemas []Value
+	var shapes []*shape
+	for _, alt := range alts {
+		s, err := r.convert(alt)
+		if err != nil {
+			return nil, err
+		}
+		if s.kinds == 0 && s.null && s.target == nil {
+			nullable = true
+			continue
+		}
+		schemas = append(schemas, alt)
+		shapes = append(shapes, s)
+	}
+
+	if len(shapes) == 1 {
+		shapes[0].null = shapes[0].null || nullable
+		return shapes[0], nil
+	}
+
+	merged := &shape{null: nullable}
+	for _, s := range shapes {
+		if s.target != nil {
+			s = s.target
+		}
+		merged.merge(s)
+	}
+
+	if merged.kinds == 1<<ObjectValue {
+		key, values := r.discriminator(schemas)
+		if key != "" {
+			merged.discriminator = key
+			merged.variants = make(map[string]*shape, len(values))
+			for i, value := range values {
+				variant := shapes[i]
+				if variant.target != nil {
+					variant = variant.target
+				}
+				merged.variants[value] = variant
+				merged.variantOrder = append(merged.variantOrder, value)
+			}
+		}
+	}
+	return merged, nil
+}
+
+// discriminator returns the first property that every schema fixes to
+// a different string, with const or a single value enum, and those strings.
+func (r *schemaReader) discriminator(schemas []Value) (string, []string) {
+	first := r.properties(schemas[0])
+	for _, candidate := range first.Object {
+		values := make([]string, 0, len(schemas))
+		for _, schema := range schemas {
+			property, _ := lookup(r.properties(schema), candidate.K)
+			value, ok := fixedString(property)
+			if !ok || slices.Contains(values, value) {
+				break
+			}
+			values = append(values, value)
+		}
+		if len(values) == len(schemas) {
+			return candidate.K, values
+		}
+	}
+	return "", nil
+}
+
+// properties returns the properties of an object schema, following a $ref.
+func (r *schemaReader) properties(schema Value) Value {
+	if ref, ok := lookup(schema, "$ref"); ok {
+		schema, _ = r.resolve(ref.Str)
+	}
+	properties, _ := lookup(schema, "properties")
+	return properties
+}
+
+// fixedString returns the only string a schema allows, if any.
+func fixedString(schema Value) (string, bool) {
+	if value, ok := lookup(schema, "const"); ok && value.Kind == StringValue {
+		return value.Str, true
+	}
+	if values, ok := lookup(schema, "enum"); ok && len(values.Array) == 1 && values.Array[0].Kind == StringValue {
+		return values.Array[0].Str, true
+	}
+	return "", false
+}
+
+// lookup returns the value of key in object v.
+func lookup(v Value, key string) (Value, bool) {
+	for _, f := range v.Object {
+		if f.K == key {
+			return f.V, true
+		}
+	}
+	return Value{}, false
+}
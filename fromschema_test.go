@@ -0,0 +1,271 @@
+package json2go
+
+import "testing"
+
+func TestTransformSchema(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		opts     Options
+		expected string
+		err      error
+	}{
+		"required, formats and nulls": {
+			input: `{
+				"type": "object",
+				"required": ["id", "name"],
+				"properties": {
+					"id": {"type": "integer", "format": "int32"},
+					"name": {"type": "string"},
+					"email": {"type": ["string", "null"], "format": "email"},
+					"created_at": {"type": "string", "format": "date-time"},
+					"score": {"type": "number"},
+					"tags": {"type": "array", "items": {"type": "string"}},
+					"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+					"extra": {"type": "object"}
+				}
+			}`,
+			opts: Options{OmitStyle: OmitEmpty, SizedInts: true},
+			expected: "type Out struct {\n" +
+				"\tID int `json:\"id\"`\n" +
+				"\tName string `json:\"name\"`\n" +
+				"\tEmail *string `json:\"email,omitempty\"`\n" +
+				"\tCreatedAt time.Time `json:\"created_at,omitempty\"`\n" +
+				"\tScore float64 `json:\"score,omitempty\"`\n" +
+				"\tTags []string `json:\"tags,omitempty\"`\n" +
+				"\tLabels map[string]string `json:\"labels,omitempty\"`\n" +
+				"\tExtra map[string]any `json:\"extra,omitempty\"`\n" +
+				"}",
+		},
+		"enums": {
+			input: `{"properties": {"status": {"enum": ["active", "in-review"]}, "level": {"enum": [1, 2, 3]}}}`,
+			opts:  Options{UnsignedInts: true},
+			expected: "type Out struct {\n" +
+				"\tStatus OutStatus `json:\"status\"`\n" +
+				"\tLevel uint64 `json:\"level\"`\n" +
+				"}\n\n" +
+				"type OutStatus string\n\n" +
+				"const (\n" +
+				"\tOutStatusActive OutStatus = \"active\"\n" +
+				"\tOutStatusInReview OutStatus = \"in-review\"\n" +
+				")",
+		},
+		"refs and recursion": {
+			input: `{
+				"type": "object",
+				"properties": {
+					"owner": {"$ref": "#/$defs/user"},
+					"members": {"type": "array", "items": {"$ref": "#/$defs/user"}},
+					"root": {"$ref": "#/$defs/node"}
+				},
+				"$defs": {
+					"user": {"type": "object", "properties": {"name": {"type": "string"}}},
+					"node": {"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "#/$defs/node"}}, "parent": {"$ref": "#/$defs/node"}}}
+				}
+			}`,
+			expected: "type Out struct {\n" +
+				"\tOwner User `json:\"owner\"`\n" +
+				"\tMembers []User `json:\"members\"`\n" +
+				"\tRoot Node `json:\"root\"`\n" +
+				"}\n\n" +
+				"type User struct {\n" +
+				"\tName string `json:\"name\"`\n" +
+				"}\n\n" +
+				"type Node struct {\n" +
+				"\tChildren []Node `json:\"children\"`\n" +
+				"\tParent *Node `json:\"parent\"`\n" +
+				"}",
+		},
+		"oneOf with null": {
+			input: `{"properties": {"owner": {"oneOf": [{"type": "null"}, {"$ref": "#/$defs/user"}]}}, "$defs": {"user": {"properties": {"name": {"type": "string"}}}}}`,
+			expected: "type Out struct {\n" +
+				"\tOwner *User `json:\"owner\"`\n" +
+				"}\n\n" +
+				"type User struct {\n" +
+				"\tName string `json:\"name\"`\n" +
+				"}",
+		},
+		"oneOf merged without unions": {
+			input: `{"type": "array", "items": {"oneOf": [
+				{"required": ["kind", "x"], "properties": {"kind": {"const": "click"}, "x": {"type": "integer"}}},
+				{"required": ["kind"], "properties": {"kind": {"const": "view"}, "page": {"type": "string"}}}
+			]}}`,
+			opts: Options{OmitStyle: OmitEmpty},
+			expected: "type Out []struct {\n" +
+				"\tKind string `json:\"kind\"`\n" +
+				"\tX int `json:\"x,omitempty\"`\n" +
+				"\tPage string `json:\"page,omitempty\"`\n" +
+				"}",
+		},
+		"oneOf as union": {
+			input: `{"type": "array", "items": {"oneOf": [
+				{"properties": {"kind": {"const": "click"}, "x": {"type": "integer"}}},
+				{"properties": {"kind": {"enum": ["view"]}}}
+			]}}`,
+			opts: Options{Unions: true},
+			expected: "type Out []OutItem\n\n" +
+				"type OutItem struct {\n" +
+				"\tValue OutItemVariant\n" +
+				"}\n\n" +
+				"// OutItemVariant is one of OutItemClick, OutItemView.\n" +
+				"type OutItemVariant interface {\n" +
+				"\tisOutItem()\n" +
+				"}\n\n" +
+				"func (u *OutItem) UnmarshalJSON(data []byte) error {\n" +
+				"\tvar probe struct {\n" +
+				"\t\tKind string `json:\"kind\"`\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(data, &probe); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tswitch probe.Kind {\n" +
+				"\tcase \"click\":\n" +
+				"\t\tu.Value = new(OutItemClick)\n" +
+				"\tcase \"view\":\n" +
+				"\t\tu.Value = new(OutItemView)\n" +
+				"\tdefault:\n" +
+				"\t\treturn fmt.Errorf(\"unknown OutItem kind %q\", probe.Kind)\n" +
+				"\t}\n" +
+				"\treturn json.Unmarshal(data, u.Value)\n" +
+				"}\n\n" +
+				"func (u OutItem) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal(u.Value)\n" +
+				"}\n\n" +
+				"type OutItemClick struct {\n" +
+				"\tKind string `json:\"kind\"`\n" +
+				"\tX int `json:\"x\"`\n" +
+				"}\n\n" +
+				"func (OutItemClick) isOutItem() {}\n\n" +
+				"type OutItemView struct {\n" +
+				"\tKind OutItemViewKind `json:\"kind\"`\n" +
+				"}\n\n" +
+				"func (OutItemView) isOutItem() {}\n\n" +
+				"type OutItemViewKind string\n\n" +
+				"const (\n" +
+				"\tOutItemViewKindView OutItemViewKind = \"view\"\n" +
+				")",
+		},
+		"union of refs shares their types": {
+			input: `{
+				"properties": {
+					"ev": {"oneOf": [{"$ref": "#/$defs/click"}, {"$ref": "#/$defs/view"}]},
+					"last": {"$ref": "#/$defs/click"}
+				},
+				"$defs": {
+					"click": {"properties": {"kind": {"const": "click"}, "x": {"type": "integer"}}},
+					"view": {"properties": {"kind": {"const": "view"}}}
+				}
+			}`,
+			opts: Options{Unions: true},
+			expected: "type Out struct {\n" +
+				"\tEv OutEv `json:\"ev\"`\n" +
+				"\tLast Click `json:\"last\"`\n" +
+				"}\n" +
+				"\n" +
+				"type OutEv struct {\n" +
+				"\tValue OutEvVariant\n" +
+				"}\n" +
+				"\n" +
+				"// OutEvVariant is one of Click, View.\n" +
+				"type OutEvVariant interface {\n" +
+				"\tisOutEv()\n" +
+				"}\n" +
+				"\n" +
+				"func (u *OutEv) UnmarshalJSON(data []byte) error {\n" +
+				"\tvar probe struct {\n" +
+				"\t\tKind string `json:\"kind\"`\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(data, &probe); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tswitch probe.Kind {\n" +
+				"\tcase \"click\":\n" +
+				"\t\tu.Value = new(Click)\n" +
+				"\tcase \"view\":\n" +
+				"\t\tu.Value = new(View)\n" +
+				"\tdefault:\n" +
+				"\t\treturn fmt.Errorf(\"unknown OutEv kind %q\", probe.Kind)\n" +
+				"\t}\n" +
+				"\treturn json.Unmarshal(data, u.Value)\n" +
+				"}\n" +
+				"\n" +
+				"func (u OutEv) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal(u.Value)\n" +
+				"}\n" +
+				"\n" +
+				"func (Click) isOutEv() {}\n" +
+				"\n" +
+				"func (View) isOutEv() {}\n" +
+				"\n" +
+				"type Click struct {\n" +
+				"\tKind string `json:\"kind\"`\n" +
+				"\tX int `json:\"x\"`\n" +
+				"}\n" +
+				"\n" +
+				"type View struct {\n" +
+				"\tKind string `json:\"kind\"`\n" +
+				"}",
+		},
+		"enum with escapes in a complete file": {
+			input: `{"required": ["mood"], "properties": {"mood": {"enum": ["\ud83d\ude00", "a\"b"]}}}`,
+			opts:  Options{PackageName: "models"},
+			expected: "// Code generated by json2go. DO NOT EDIT.\n\n" +
+				"package models\n\n" +
+				"type Out struct {\n" +
+				"\tMood OutMood `json:\"mood\"`\n" +
+				"}\n\n" +
+				"type OutMood string\n\n" +
+				"const (\n" +
+				"\tOutMoodField OutMood = \"😀\"\n" +
+				"\tOutMoodAB    OutMood = \"a\\\"b\"\n" +
+				")\n",
+		},
+		"root ref": {
+			input:    `{"$ref": "#/$defs/user", "$defs": {"user": {"properties": {"name": {"type": "string"}}}}}`,
+			expected: "type Out struct {\n\tName string `json:\"name\"`\n}",
+		},
+		"unresolvable ref": {
+			input: `{"properties": {"a": {"$ref": "#/$defs/missing"}}}`,
+			err:   ErrInvalidSchema,
+		},
+		"external ref": {
+			input: `{"properties": {"a": {"$ref": "other.json#/a"}}}`,
+			err:   ErrInvalidSchema,
+		},
+		"unknown type": {
+			input: `{"type": "float"}`,
+			err:   ErrInvalidSchema,
+		},
+		"invalid json": {
+			input: `{"type":object}`,
+			err:   ErrInvalidJSON,
+		},
+	}
+
+	for tname, tt := range tests {
+		t.Run(tname, func(t *testing.T) {
+			result, err := TransformSchema("Out", tt.input, tt.opts)
+			assertEqualErr(t, tt.err, err)
+			if tt.err == nil && result != tt.expected {
+				t.Errorf("wrong output\nexpected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestGoStringLiteral(t *testing.T) {
+	tests := map[string]string{
+		`plain`:          `"plain"`,
+		`a\/b`:           `"a/b"`,
+		`quote \" \\ \n`: `"quote \" \\ \n"`,
+		`\\/`:            `"\\/"`,
+		`\ud83d\ude00`:   `"😀"`,
+		`a\ud83d\ude00b`: `"a😀b"`,
+		`\ud83d`:         "\"\ufffd\"",
+		`\u00e9\u0007`:   `"é\a"`,
+	}
+	for raw, expected := range tests {
+		if got := goStringLiteral(raw); got != expected {
+			t.Errorf("goStringLiteral(%q): expected %s, got %s", raw, expected, got)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+package json2go
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// shape is the type inferred by merging one or more json values.
+type shape struct {
+	kinds   kindSet // kinds of non-null values observed
+	null    bool    // a null value was observed
+	objects int     // number of objects merged into [fields]
+
+	ints   int   // number of integers merged into [minInt] and [maxInt]
+	minInt int64 // smallest integer observed
+	maxInt int64 // largest integer observed
+
+	bigInts    int  // number of integers that overflow int64
+	overUint64 bool // an integer that overflows uint64, or is negative, was observed
+
+	strings int      // number of strings merged into [format]
+	format  string   // format shared by all strings, or "" if none or they disagree
+	enum    []string // allowed strings, as written in json, if restricted by a schema
+
+	fields []*shapeField  // union of object fields, in first-seen order
+	index  map[string]int // json key -> position in [fields]
+	elem   *shape         // merged type of all array elements
+
+	mapValue *shape // merged type of all field values, if the object is typed as a map
+
+	discriminator string            // key telling apart object variants, see [inferConfig]
+	variants      map[string]*shape // objects by their discriminator value
+	variantOrder  []string          // discriminator values, in first-seen order
+	untagged      bool              // an object without the discriminator was observed
+
+	name   string // type name of a shape defined by a schema, see [schemaReader]
+	target *shape // named shape this one refers to, whose fields it stands for
+}
+
+// inferConfig configures [infer].
+type inferConfig struct {
+	// format classifies strings, may be nil.
+	format formatFunc
+
+	// discriminators lists keys, in order of preference, whose string value
+	// tells apart object variants; objects are also merged per variant.
+	discriminators []string
+}
+
+// formatFunc classifies a string value, returning the name of its format,
+// e.g. "date-time", or "" if the string has no recognized format.
+type formatFunc func(s string) string
+
+type shapeField struct {
+	key   string
+	shape *shape
+	count int // number of objects the key was present in
+}
+
+// kindSet is a set of [ValueType].
+type kindSet uint8
+
+func (k kindSet) has(t ValueType) bool { return k&(1<<t) != 0 }
+
+// single reports the only kind in the set, if there is exactly one.
+func (k kindSet) single() (ValueType, bool) {
+	for t := range ArrayValue + 1 {
+		if k == 1<<t {
+			return t, true
+		}
+	}
+	return NullValue, false
+}
+
+// kind resolves the single kind all observed non-null values agree on.
+// Integers mixed with decimals resolve to [DecimalValue].
+// Returns false if no non-null value was observed, or if the kinds conflict.
+func (s *shape) kind() (ValueType, bool) {
+	if s.kinds == 1<<NumberValue|1<<DecimalValue {
+		return DecimalValue, true
+	}
+	return s.kinds.single()
+}
+
+// fitsInt32 reports whether every integer observed fits into an int32.
+func (s *shape) fitsInt32() bool {
+	return s.minInt >= math.MinInt32 && s.maxInt <= math.MaxInt32
+}
+
+// onlyNull reports whether null is the only value observed,
+// looking through arrays at their elements.
+func (s *shape) onlyNull() bool {
+	for s.kinds == 1<<ArrayValue {
+		s = s.elem
+	}
+	return s.null && s.kinds == 0
+}
+
+// infer merges v, and every element of every array inside it, into a single shape.
+func infer(v Value, cfg inferConfig) *shape {
+	s := &shape{}
+	s.add(v, &cfg)
+	return s
+}
+
+// inferSamples merges every sample into a single shape, like the elements
+// of an array, so that fields missing in some samples are optional.
+// It stops at the first error yielded by samples.
+func inferSamples(samples iter.Seq2[Value, error], cfg inferConfig) (*shape, error) {
+	s := &shape{}
+	for v, err := range samples {
+		if err != nil {
+			return nil, err
+		}
+		s.add(v, &cfg)
+	}
+	return s, nil
+}
+
+// valueSeq yields the values of a slice as samples for [inferSamples].
+func valueSeq(values []Value) iter.Seq2[Value, error] {
+	return func(yield func(Value, error) bool) {
+		for _, v := range values {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s *shape) add(v Value, cfg *inferConfig) {
+	if v.Kind == NullValue {
+		s.null = true
+		return
+	}
+
+	s.kinds |= 1 << v.Kind
+	switch v.Kind {
+	case NumberValue:
+		if v.Raw != "" {
+			if _, err := strconv.ParseUint(v.Raw, 10, 64); err != nil {
+				s.overUint64 = true
+			}
+			s.bigInts++
+			break
+		}
+		if s.ints == 0 || v.Int < s.minInt {
+			s.minInt = v.Int
+		}
+		if s.ints == 0 || v.Int > s.maxInt {
+			s.maxInt = v.Int
+		}
+		s.ints++
+
+	case StringValue:
+		var f string
+		if cfg.format != nil {
+			f = cfg.format(v.Str)
+		}
+		if s.strings == 0 {
+			s.format = f
+		} else {
+			s.format = mergeFormat(s.format, f)
+		}
+		s.strings++
+
+	case ObjectValue:
+		s.addFields(v, cfg)
+		if len(cfg.discriminators) > 0 {
+			s.addVariant(v, cfg)
+		}
+
+	case ArrayValue:
+		if s.elem == nil {
+			s.elem = &shape{}
+		}
+		for _, item := range v.Array {
+			s.elem.add(item, cfg)
+		}
+	}
+}
+
+func (s *shape) addFields(v Value, cfg *inferConfig) {
+	s.objects++
+	for _, f := range v.Object {
+		field := s.field(f.K)
+		field.count++
+		field.shape.add(f.V, cfg)
+	}
+}
+
+// addVariant merges object v into the variant selected by its discriminator.
+func (s *shape) addVariant(v Value, cfg *inferConfig) {
+	key, value, ok := discriminate(v.Object, cfg.discriminators)
+	if !ok || s.discriminator != "" && key != s.discriminator {
+		s.untagged = true
+		return
+	}
+
+	s.discriminator = key
+	variant := s.variant(value)
+	variant.kinds |= 1 << ObjectValue
+	variant.addFields(v, cfg)
+}
+
+// discriminate returns the first of keys present in fields with a string value.
+func discriminate(fields []Field, keys []string) (key, value string, ok bool) {
+	for _, key := range keys {
+		for _, f := range fields {
+			if f.K == key && f.V.Kind == StringValue {
+				return key, f.V.Str, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// variant returns the shape of objects with the given discriminator value,
+// creating it if this is the first time the value is seen.
+func (s *shape) variant(value string) *shape {
+	if s.variants == nil {
+		s.variants = make(map[string]*shape)
+	}
+	v, ok := s.variants[value]
+	if !ok {
+		v = &shape{}
+		s.variants[value] = v
+		s.variantOrder = append(s.variantOrder, value)
+	}
+	return v
+}
+
+// isUnion reports whether s holds objects of at least two variants
+// that can all be told apart by their discriminator.
+func (s *shape) isUnion() bool {
+	return s.kinds == 1<<ObjectValue && !s.untagged && len(s.variants) > 1
+}
+
+// mixedKinds lists the kinds of values that conflict in s, looking through
+// arrays at their elements, or returns "" if there is no conflict.
+func (s *shape) mixedKinds() string {
+	for s.kinds == 1<<ArrayValue {
+		s = s.elem
+	}
+	if _, ok := s.kind(); ok || s.kinds == 0 {
+		return ""
+	}
+
+	var names []string
+	for kind := range ArrayValue + 1 {
+		if name := kindNames[kind]; s.kinds.has(kind) && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// kindNames names each [ValueType] in comments of the generated code.
+var kindNames = [...]string{
+	NullValue:    "null",
+	BoolValue:    "bool",
+	StringValue:  "string",
+	NumberValue:  "number",
+	DecimalValue: "number",
+	ObjectValue:  "object",
+	ArrayValue:   "array",
+}
+
+// merge merges the values observed by o into s.
+//
+// Shapes referring to a named shape are merged by reference, so that
+// recursive types stay finite. A shape referring to one named shape
+// ignores the fields of everything merged into it.
+func (s *shape) merge(o *shape) {
+	if o.target != nil && s.target == nil && s.kinds == 0 {
+		s.target = o.target
+	}
+	if s.target != nil {
+		s.kinds |= o.kinds
+		s.null = s.null || o.null
+		s.objects += o.objects
+		return
+	}
+
+	s.kinds |= o.kinds
+	s.null = s.null || o.null
+	s.objects += o.objects
+
+	if o.ints > 0 {
+		if s.ints == 0 || o.minInt < s.minInt {
+			s.minInt = o.minInt
+		}
+		if s.ints == 0 || o.maxInt > s.maxInt {
+			s.maxInt = o.maxInt
+		}
+		s.ints += o.ints
+	}
+	s.bigInts += o.bigInts
+	s.overUint64 = s.overUint64 || o.overUint64
+
+	if o.strings > 0 {
+		if s.strings == 0 {
+			s.format = o.format
+			s.enum = slices.Clone(o.enum)
+		} else {
+			s.format = mergeFormat(s.format, o.format)
+			s.enum = mergeEnum(s.enum, o.enum)
+		}
+		s.strings += o.strings
+	}
+
+	for _, f := range o.fields {
+		field := s.field(f.key)
+		field.count += f.count
+		field.shape.merge(f.shape)
+	}
+
+	if o.elem != nil {
+		if s.elem == nil {
+			s.elem = &shape{}
+		}
+		s.elem.merge(o.elem)
+	}
+
+	s.untagged = s.untagged || o.untagged
+	if s.discriminator == "" {
+		s.discriminator = o.discriminator
+	} else if o.discriminator != "" && o.discriminator != s.discriminator {
+		s.untagged = true
+	}
+	for _, value := range o.variantOrder {
+		s.variant(value).merge(o.variants[value])
+	}
+}
+
+// mergeFormat returns the format shared by strings of formats a and b,
+// quoted integers widen to quoted decimals.
+func mergeFormat(a, b string) string {
+	switch {
+	case a == b:
+		return a
+	case a == "quoted-int" && b == "quoted-float", a == "quoted-float" && b == "quoted-int":
+		return "quoted-float"
+	default:
+		return ""
+	}
+}
+
+// mergeEnum returns the strings allowed by either enum a or b,
+// or nil if either allows any string.
+func mergeEnum(a, b []string) []string {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	for _, value := range b {
+		if !slices.Contains(a, value) {
+			a = append(a, value)
+		}
+	}
+	return a
+}
+
+// field returns the object field with the given key,
+// creating it if this is the first time the key is seen.
+func (s *shape) field(key string) *shapeField {
+	if s.index == nil {
+		s.index = make(map[string]int)
+	}
+
+	i, ok := s.index[key]
+	if !ok {
+		i = len(s.fields)
+		s.index[key] = i
+		s.fields = append(s.fields, &shapeField{key: key, shape: &shape{}})
+	}
+
+	return s.fields[i]
+}
+
+// signature returns a canonical description of the shape, equal for shapes
+// that would produce the same type regardless of their field order.
+// Integer ranges are only told apart if ranges is set, for backends
+// whose integer types depend on them.
+func (s *shape) signature(ranges bool) string {
+	var buf strings.Builder
+	s.writeSignature(&buf, ranges)
+	return buf.String()
+}
+
+func (s *shape) writeSignature(buf *strings.Builder, ranges bool) {
+	buf.WriteString(strconv.Itoa(int(s.kinds)))
+	if s.null {
+		buf.WriteByte('?')
+	}
+	if s.target != nil { // named shapes are unique, and may be recursive
+		buf.WriteByte('@')
+		buf.WriteString(s.target.name)
+		return
+	}
+	if ranges {
+		if s.minInt < 0 {
+			buf.WriteByte('-')
+		}
+		if !s.fitsInt32() {
+			buf.WriteByte('L')
+		}
+		if s.overUint64 {
+			buf.WriteByte('U')
+		}
+	}
+	if s.bigInts > 0 {
+		buf.WriteByte('B')
+	}
+	if s.format != "" {
+		buf.WriteByte('(')
+		buf.WriteString(s.format)
+		buf.WriteByte(')')
+	}
+	for _, value := range s.enum {
+		buf.WriteByte('|')
+		buf.WriteString(strconv.Quote(value))
+	}
+
+	if s.isUnion() {
+		buf.WriteByte('<')
+		buf.WriteString(strconv.Quote(s.discriminator))
+		for _, value := range s.variantOrder {
+			buf.WriteByte(',')
+			buf.WriteString(strconv.Quote(value))
+			buf.WriteByte(':')
+			s.variants[value].writeSignature(buf, ranges)
+		}
+		buf.WriteByte('>')
+	}
+	if s.mapValue != nil {
+		buf.WriteString("map[")
+		s.mapValue.writeSignature(buf, ranges)
+		buf.WriteByte(']')
+	} else if len(s.fields) > 0 {
+		fields := slices.Clone(s.fields)
+		slices.SortFunc(fields, func(a, b *shapeField) int { return strings.Compare(a.key, b.key) })
+
+		buf.WriteByte('{')
+		for _, f := range fields {
+			buf.WriteString(strconv.Quote(f.key))
+			if f.count < s.objects {
+				buf.WriteByte('?')
+			}
+			buf.WriteByte(':')
+			f.shape.writeSignature(buf, ranges)
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('}')
+	}
+
+	if s.elem != nil {
+		buf.WriteByte('[')
+		s.elem.writeSignature(buf, ranges)
+		buf.WriteByte(']')
+	}
+}
@@ -0,0 +1,133 @@
+package json2go
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInfer(t *testing.T) {
+	parse := func(t *testing.T, input string) Value {
+		t.Helper()
+		v, err := NewParser(NewLexer([]byte(input))).Parse()
+		if err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		return v
+	}
+
+	t.Run("array of objects merges fields", func(t *testing.T) {
+		s := infer(parse(t, `[{"a": 1}, {"b": "x"}, {"a": 2, "c": true}]`), inferConfig{})
+		if s.elem == nil {
+			t.Fatalf("expected array element shape")
+		}
+
+		keys := []string{"a", "b", "c"}
+		counts := []int{2, 1, 1}
+		if len(s.elem.fields) != len(keys) {
+			t.Fatalf("expected %d fields, got %d", len(keys), len(s.elem.fields))
+		}
+		for i, f := range s.elem.fields {
+			if f.key != keys[i] {
+				t.Errorf("field %d: expected key %q, got %q", i, keys[i], f.key)
+			}
+			if f.count != counts[i] {
+				t.Errorf("field %q: expected count %d, got %d", f.key, counts[i], f.count)
+			}
+		}
+		if s.elem.objects != 3 {
+			t.Errorf("expected 3 objects, got %d", s.elem.objects)
+		}
+	})
+
+	t.Run("nested arrays merge recursively", func(t *testing.T) {
+		s := infer(parse(t, `[{"tags": [{"x": 1}]}, {"tags": [{"y": 2}]}]`), inferConfig{})
+		tags := s.elem.fields[0].shape.elem
+		if len(tags.fields) != 2 {
+			t.Fatalf("expected 2 nested fields, got %d", len(tags.fields))
+		}
+	})
+
+	t.Run("string formats must agree", func(t *testing.T) {
+		format := func(s string) string {
+			if s == "now" {
+				return "date-time"
+			}
+			return ""
+		}
+
+		s := infer(parse(t, `{"a": ["now", "now"], "b": ["now", "later"]}`), inferConfig{format: format})
+		if f := s.fields[0].shape.elem.format; f != "date-time" {
+			t.Errorf("expected date-time format, got %q", f)
+		}
+		if f := s.fields[1].shape.elem.format; f != "" {
+			t.Errorf("expected no format, got %q", f)
+		}
+	})
+
+	t.Run("integer range", func(t *testing.T) {
+		s := infer(parse(t, `[3, -7, 5000000000]`), inferConfig{})
+		if s.elem.minInt != -7 || s.elem.maxInt != 5000000000 {
+			t.Errorf("expected range [-7, 5000000000], got [%d, %d]", s.elem.minInt, s.elem.maxInt)
+		}
+		if s.elem.fitsInt32() {
+			t.Errorf("expected range not to fit int32")
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		s := infer(parse(t, `[{"a": 1, "b": "x"}, {"a": -3}]`), inferConfig{})
+		o := infer(parse(t, `[{"a": 7, "c": null}]`), inferConfig{})
+		s.merge(o)
+
+		if s.elem.objects != 3 {
+			t.Errorf("expected 3 objects, got %d", s.elem.objects)
+		}
+		a := s.elem.fields[0]
+		if a.count != 3 || a.shape.minInt != -3 || a.shape.maxInt != 7 {
+			t.Errorf("expected a in 3 objects in [-3, 7], got %d in [%d, %d]", a.count, a.shape.minInt, a.shape.maxInt)
+		}
+		if len(s.elem.fields) != 3 || !s.elem.fields[2].shape.null {
+			t.Errorf("expected null field c to be merged")
+		}
+	})
+
+	t.Run("null is tracked separately", func(t *testing.T) {
+		s := infer(parse(t, `[1, null, 2]`), inferConfig{})
+		if kind, ok := s.elem.kinds.single(); !ok || kind != NumberValue {
+			t.Errorf("expected single number kind, got %v", s.elem.kinds)
+		}
+		if !s.elem.null {
+			t.Errorf("expected null to be recorded")
+		}
+	})
+	t.Run("objects are split into variants by discriminator", func(t *testing.T) {
+		cfg := inferConfig{discriminators: []string{"op", "type"}}
+		s := infer(parse(t, `[{"type": "a", "op": "add", "n": 1}, {"type": "a", "op": "del"}, {"op": "add"}]`), cfg)
+		if !s.elem.isUnion() || s.elem.discriminator != "op" {
+			t.Fatalf("expected union on op, got %q", s.elem.discriminator)
+		}
+		if !slices.Equal(s.elem.variantOrder, []string{"add", "del"}) {
+			t.Errorf("unexpected variants %v", s.elem.variantOrder)
+		}
+		if add := s.elem.variants["add"]; add.objects != 2 || len(add.fields) != 3 {
+			t.Errorf("expected both add objects merged, got %d objects, %d fields", add.objects, len(add.fields))
+		}
+	})
+
+	t.Run("untagged object prevents a union", func(t *testing.T) {
+		cfg := inferConfig{discriminators: []string{"type"}}
+		s := infer(parse(t, `[{"type": "a"}, {"type": "b"}, {"name": "c"}]`), cfg)
+		if s.elem.isUnion() {
+			t.Errorf("expected no union")
+		}
+	})
+
+	t.Run("mixed kinds", func(t *testing.T) {
+		s := infer(parse(t, `{"a": [1, "x", 2.5, null], "b": [1, 2.5], "c": [[true, {}]]}`), inferConfig{})
+		for i, expected := range []string{"string, number", "", "bool, object"} {
+			if mixed := s.fields[i].shape.mixedKinds(); mixed != expected {
+				t.Errorf("field %s: expected %q, got %q", s.fields[i].key, expected, mixed)
+			}
+		}
+	})
+}
@@ -2,6 +2,10 @@ package json2go
 
 import (
 	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"io"
 	"unicode"
 	"unicode/utf8"
 	"unsafe"
@@ -11,8 +15,16 @@ var (
 	// ErrInvalidJSON json input could not be parsed.
 	ErrInvalidJSON = errors.New("invalid json")
 
-	// ErrInvalidStructName struct name provided is not a valid Go identifier.
+	// ErrInvalidStructName struct name provided is not a valid Go identifier,
+	// or is a keyword or predeclared identifier like "type" or "string".
 	ErrInvalidStructName = errors.New("invalid struct name")
+
+	// ErrInvalidPackageName package name provided is not a valid Go identifier.
+	ErrInvalidPackageName = errors.New("invalid package name")
+
+	// ErrInvalidSchema json schema could not be converted to types, e.g. a $ref
+	// could not be resolved.
+	ErrInvalidSchema = errors.New("invalid json schema")
 )
 
 // Transform converts a JSON string to Go struct type definitions.
@@ -21,23 +33,144 @@ var (
 // Set includeTags to true to generate `json:"field_name"` tags on struct fields.
 // Returns the Go code as a string, or an error if JSON parsing fails.
 func Transform(structName, jsonStr string, includeTags bool) (string, error) {
-	if !isValidIdentifier(structName) {
+	return TransformWithOptions(structName, jsonStr, Options{OmitTags: !includeTags})
+}
+
+// TransformWithOptions converts a JSON string to Go type definitions
+// generated as configured by opts.
+//
+// The structName must be a valid Go identifier.
+// Returns the Go code as a string, or an error if JSON parsing fails.
+func TransformWithOptions(structName, jsonStr string, opts Options) (string, error) {
+	if !isValidTypeName(structName) {
 		return "", ErrInvalidStructName
 	}
 
+	v, err := parseJSON(jsonStr)
+	if err != nil {
+		return "", err
+	}
+
+	return NewTranspilerWithOptions(opts).Generate(structName, v)
+}
+
+// TransformSamples converts several JSON strings, samples of the same document,
+// to Go type definitions of a single type merging them, generated as
+// configured by opts, see [Transpiler.GenerateSamples]. Fields missing in
+// some samples are marked as optional only if opts.OmitStyle is set.
+//
+// The structName must be a valid Go identifier.
+// Returns the Go code as a string, or an error if parsing any of the samples fails.
+func TransformSamples(structName string, samples []string, opts Options) (string, error) {
+	if !isValidTypeName(structName) {
+		return "", ErrInvalidStructName
+	}
+
+	values := make([]Value, len(samples))
+	for i, sample := range samples {
+		v, err := parseJSON(sample)
+		if err != nil {
+			return "", fmt.Errorf("sample %d: %w", i+1, err)
+		}
+		values[i] = v
+	}
+
+	return NewTranspilerWithOptions(opts).GenerateSamples(structName, values)
+}
+
+// TransformNDJSON converts newline-delimited json (JSON Lines) read from r,
+// one sample per line, to Go type definitions of a single type merging them,
+// generated as configured by opts, see [Transpiler.GenerateSamples]. Fields
+// missing in some lines are marked as optional only if opts.OmitStyle is set.
+//
+// The input is parsed and merged line by line, so it is never held in memory
+// as a whole. Blank lines are skipped.
+// Returns the Go code as a string, or an error if reading r or parsing a line fails.
+func TransformNDJSON(structName string, r io.Reader, opts Options) (string, error) {
+	return NewTranspilerWithOptions(opts).generate(structName, ndjsonSamples(r))
+}
+
+// TransformSchema converts a JSON Schema document to Go type definitions
+// generated as configured by opts, see [Transpiler.GenerateFromSchema].
+//
+// The structName must be a valid Go identifier.
+// Returns the Go code as a string, or an error if the schema cannot be parsed or converted.
+func TransformSchema(structName, schemaStr string, opts Options) (string, error) {
+	if !isValidTypeName(structName) {
+		return "", ErrInvalidStructName
+	}
+
+	schema, err := parseJSON(schemaStr)
+	if err != nil {
+		return "", err
+	}
+
+	return NewTranspilerWithOptions(opts).GenerateFromSchema(structName, schema)
+}
+
+// TransformToSchema converts a JSON string to a JSON Schema (draft 2020-12)
+// document describing it, inferred as configured by opts.
+//
+// Returns the schema as an indented JSON string, or an error if JSON parsing fails.
+func TransformToSchema(title, jsonStr string, opts Options) (string, error) {
+	v, err := parseJSON(jsonStr)
+	if err != nil {
+		return "", err
+	}
+
+	return NewSchemaGenerator(opts).Generate(title, v)
+}
+
+// TransformToTypeScript converts a JSON string to exported TypeScript
+// declarations generated as configured by opts.
+//
+// The name must be a valid identifier.
+// Returns the TypeScript code as a string, or an error if JSON parsing fails.
+func TransformToTypeScript(name, jsonStr string, opts Options) (string, error) {
+	if !isValidTypeName(name) {
+		return "", ErrInvalidStructName
+	}
+
+	v, err := parseJSON(jsonStr)
+	if err != nil {
+		return "", err
+	}
+
+	return NewTypeScriptGenerator(opts).Generate(name, v)
+}
+
+// TransformToProto converts a JSON string to a proto3 file with a message
+// of the given name, generated as configured by opts.
+//
+// Returns the .proto file as a string, or an error if JSON parsing fails.
+func TransformToProto(messageName, jsonStr string, opts Options) (string, error) {
+	v, err := parseJSON(jsonStr)
+	if err != nil {
+		return "", err
+	}
+
+	return NewProtoGenerator(opts).Generate(messageName, v)
+}
+
+func parseJSON(jsonStr string) (Value, error) {
 	input := unsafe.Slice(unsafe.StringData(jsonStr), len(jsonStr))
 	lexer := NewLexer(input)
 	parser := NewParser(lexer)
 	v, err := parser.Parse()
 	if err != nil {
-		return "", errors.Join(ErrInvalidJSON, err)
+		return Value{}, errors.Join(ErrInvalidJSON, err)
 	}
+	return v, nil
+}
 
-	return NewTranspiler().Transpile(structName, v, includeTags)
+// isValidTypeName reports whether s can be declared as a type without
+// shadowing a predeclared identifier, which would break the generated code.
+func isValidTypeName(s string) bool {
+	return isValidIdentifier(s) && types.Universe.Lookup(s) == nil
 }
 
 func isValidIdentifier(s string) bool {
-	if len(s) == 0 {
+	if len(s) == 0 || token.IsKeyword(s) {
 		return false
 	}
 
@@ -2,6 +2,10 @@ package json2go
 
 import (
 	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -46,6 +50,14 @@ func TestTransform(t *testing.T) {
 			err:        ErrInvalidStructName,
 			structName: "Name$",
 		},
+		"invalid struct name, keyword": {
+			err:        ErrInvalidStructName,
+			structName: "type",
+		},
+		"invalid struct name, predeclared identifier": {
+			err:        ErrInvalidStructName,
+			structName: "string",
+		},
 		"snake_case to CamelCase": {
 			input: `{"first_name": "Bob", "last_name": "Bobberson"}`,
 			check: func(t *testing.T, result string) {
@@ -57,6 +69,31 @@ func TestTransform(t *testing.T) {
 				}
 			},
 		},
+		"kebab, dotted, spaced and camelCase keys": {
+			input: `{"first-name": "a", "last.name": "b", "middle name": "c", "nickName": "d"}`,
+			check: func(t *testing.T, result string) {
+				for _, field := range []string{
+					"FirstName string `json:\"first-name\"`",
+					"LastName string `json:\"last.name\"`",
+					"MiddleName string `json:\"middle name\"`",
+					"NickName string `json:\"nickName\"`",
+				} {
+					if !strings.Contains(result, field) {
+						t.Errorf("missing %q field, got: %s", field, result)
+					}
+				}
+			},
+		},
+		"initialisms": {
+			input: `{"user_id": 1, "avatar_url": "a", "http_status": 200, "api": "v1"}`,
+			check: func(t *testing.T, result string) {
+				for _, field := range []string{"UserID int", "AvatarURL string", "HTTPStatus int", "API string"} {
+					if !strings.Contains(result, field) {
+						t.Errorf("missing %q field, got: %s", field, result)
+					}
+				}
+			},
+		},
 		"nested object and array": {
 			input: `{"user": {"name": "Alice", "score": 95.5}, "tags": ["go", "json"]}`,
 			check: func(t *testing.T, result string) {
@@ -96,6 +133,33 @@ func TestTransform(t *testing.T) {
 				}
 			},
 		},
+		"array of objects with different keys": {
+			input: `[{"a": 1}, {"b": "x"}]`,
+			check: func(t *testing.T, result string) {
+				if !strings.Contains(result, "A int `json:\"a\"`") {
+					t.Errorf("missing A field, got: %s", result)
+				}
+				if !strings.Contains(result, "B string `json:\"b\"`") {
+					t.Errorf("missing B field from second element, got: %s", result)
+				}
+			},
+		},
+		"array of ints and decimals": {
+			input: `{"scores": [1, 2.5]}`,
+			check: func(t *testing.T, result string) {
+				if !strings.Contains(result, "Scores []float64 `json:\"scores\"`") {
+					t.Errorf("expected float64 elements, got: %s", result)
+				}
+			},
+		},
+		"array of mixed kinds": {
+			input: `{"items": [1, "two"]}`,
+			check: func(t *testing.T, result string) {
+				if !strings.Contains(result, "Items []any `json:\"items\"`") {
+					t.Errorf("expected any elements, got: %s", result)
+				}
+			},
+		},
 		"empty array": {
 			input: `{"items": []}`,
 			check: func(t *testing.T, result string) {
@@ -112,6 +176,14 @@ func TestTransform(t *testing.T) {
 				}
 			},
 		},
+		"sometimes null": {
+			input: `[{"age": null}, {"age": 5}]`,
+			check: func(t *testing.T, result string) {
+				if !strings.Contains(result, "Age *int `json:\"age\"`") {
+					t.Errorf("expected pointer Age field, got: %s", result)
+				}
+			},
+		},
 		"numbers": {
 			input: `{"pos": 123, "neg": -321, "float": 420.69}`,
 			check: func(t *testing.T, result string) {
@@ -144,6 +216,573 @@ func TestTransform(t *testing.T) {
 	}
 }
 
+func TestTransformWithOptions(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		opts     Options
+		expected string
+		err      error
+	}{
+		"zero options match Transform": {
+			input:    `{"first_name": "Bob"}`,
+			expected: "type Out struct {\n\tFirstName string `json:\"first_name\"`\n}",
+		},
+		"omit tags": {
+			input:    `{"first_name": "Bob"}`,
+			opts:     Options{OmitTags: true},
+			expected: "type Out struct {\n\tFirstName string\n}",
+		},
+		"multiple tag keys": {
+			input:    `{"first_name": "Bob"}`,
+			opts:     Options{Tags: []string{"json", "yaml"}},
+			expected: "type Out struct {\n\tFirstName string `json:\"first_name\" yaml:\"first_name\"`\n}",
+		},
+		"empty tag keys are ignored": {
+			input:    `{"first_name": "Bob"}`,
+			opts:     Options{Tags: []string{"json", "", " yaml "}},
+			expected: "type Out struct {\n\tFirstName string `json:\"first_name\" yaml:\"first_name\"`\n}",
+		},
+		"only empty tag keys": {
+			input:    `{"first_name": "Bob"}`,
+			opts:     Options{Tags: []string{""}},
+			expected: "type Out struct {\n\tFirstName string `json:\"first_name\"`\n}",
+		},
+		"named types and null style": {
+			input: `[{"user": {"age": 1}}, {"user": {"age": null}}]`,
+			opts:  Options{NamedTypes: true, NullStyle: NullSQL},
+			expected: "type Out []OutItem\n\n" +
+				"type OutItem struct {\n\tUser OutItemUser `json:\"user\"`\n}\n\n" +
+				"type OutItemUser struct {\n\tAge sql.NullInt64 `json:\"age\"`\n}",
+		},
+		"omitempty on optional fields": {
+			input:    `[{"id": 1, "age": 1}, {"id": 2}]`,
+			opts:     Options{OmitStyle: OmitEmpty},
+			expected: "type Out []struct {\n\tID int `json:\"id\"`\n\tAge int `json:\"age,omitempty\"`\n}",
+		},
+		"omitzero on nested optional fields": {
+			input:    `{"items": [{"id": 1}, {"name": "x"}]}`,
+			opts:     Options{OmitStyle: OmitZero, Tags: []string{"json", "yaml"}},
+			expected: "type Out struct {\n\tItems []struct {\n\t\tID int `json:\"id,omitzero\" yaml:\"id\"`\n\t\tName string `json:\"name,omitzero\" yaml:\"name\"`\n\t} `json:\"items\" yaml:\"items\"`\n}",
+		},
+		"colliding field names": {
+			input: `{"user_id": 1, "userId": 2, "a-b": {"x": 1}, "a_b": {"y": 2}}`,
+			opts:  Options{NamedTypes: true},
+			expected: "type Out struct {\n" +
+				"\tUserID int `json:\"user_id\"`\n" +
+				"\tUserID2 int `json:\"userId\"`\n" +
+				"\tAB OutAB `json:\"a-b\"`\n" +
+				"\tAB2 OutAB2 `json:\"a_b\"`\n" +
+				"}\n\n" +
+				"type OutAB struct {\n\tX int `json:\"x\"`\n}\n\n" +
+				"type OutAB2 struct {\n\tY int `json:\"y\"`\n}",
+		},
+		"colliding nested type names": {
+			input: `{"a_b": {"x": 1}, "a": {"b": {"y": 1}}}`,
+			opts:  Options{NamedTypes: true},
+			expected: "type Out struct {\n" +
+				"\tAB OutAB `json:\"a_b\"`\n" +
+				"\tA OutA `json:\"a\"`\n" +
+				"}\n\n" +
+				"type OutAB struct {\n\tX int `json:\"x\"`\n}\n\n" +
+				"type OutA struct {\n\tB OutAB2 `json:\"b\"`\n}\n\n" +
+				"type OutAB2 struct {\n\tY int `json:\"y\"`\n}",
+		},
+		"keyword package name": {
+			input: `{"id": 1}`,
+			opts:  Options{PackageName: "func"},
+			err:   ErrInvalidPackageName,
+		},
+		"sized ints": {
+			input:    `[{"small": 1, "big": 5000000000, "neg": -5000000000}, {"small": -2147483648, "big": 1, "neg": 1}]`,
+			opts:     Options{SizedInts: true},
+			expected: "type Out []struct {\n\tSmall int `json:\"small\"`\n\tBig int64 `json:\"big\"`\n\tNeg int64 `json:\"neg\"`\n}",
+		},
+		"unsigned ints": {
+			input:    `[{"count": 1, "delta": 1}, {"count": 0, "delta": -1}]`,
+			opts:     Options{UnsignedInts: true},
+			expected: "type Out []struct {\n\tCount uint64 `json:\"count\"`\n\tDelta int `json:\"delta\"`\n}",
+		},
+		"decimal in any sample promotes to float64": {
+			input:    `[{"price": 1}, {"price": 2.5}, {"price": 3}]`,
+			opts:     Options{SizedInts: true, UnsignedInts: true},
+			expected: "type Out []struct {\n\tPrice float64 `json:\"price\"`\n}",
+		},
+		"big ints as json.Number": {
+			input:    `{"id": 18446744073709551615}`,
+			expected: "type Out struct {\n\tID json.Number `json:\"id\"`\n}",
+		},
+		"big ints as uint64": {
+			input:    `[{"id": 18446744073709551615, "neg": -9223372036854775809, "huge": 18446744073709551616}, {"id": 1, "neg": 1, "huge": 1}]`,
+			opts:     Options{BigIntStyle: BigIntUint64},
+			expected: "type Out []struct {\n\tID uint64 `json:\"id\"`\n\tNeg json.Number `json:\"neg\"`\n\tHuge json.Number `json:\"huge\"`\n}",
+		},
+		"big ints as big.Int": {
+			input: `[{"id": 18446744073709551616}, {"id": null}]`,
+			opts:  Options{BigIntStyle: BigIntBig, PackageName: "models"},
+			expected: "// Code generated by json2go. DO NOT EDIT.\n\n" +
+				"package models\n\n" +
+				"import (\n\t\"math/big\"\n)\n\n" +
+				"type Out []struct {\n\tID *big.Int `json:\"id\"`\n}\n",
+		},
+		"json.Number for all numbers": {
+			input: `{"count": 1, "price": 9.99, "id": 18446744073709551616, "name": "x"}`,
+			opts:  Options{NumberType: "encoding/json.Number", PackageName: "models", SizedInts: true},
+			expected: "// Code generated by json2go. DO NOT EDIT.\n\n" +
+				"package models\n\n" +
+				"import (\n\t\"encoding/json\"\n)\n\n" +
+				"type Out struct {\n" +
+				"\tCount json.Number `json:\"count\"`\n" +
+				"\tPrice json.Number `json:\"price\"`\n" +
+				"\tID    json.Number `json:\"id\"`\n" +
+				"\tName  string      `json:\"name\"`\n" +
+				"}\n",
+		},
+		"decimal type for all numbers": {
+			input:    `[{"price": 9.99}, {"price": 10}, {"price": null}]`,
+			opts:     Options{NumberType: "github.com/shopspring/decimal.Decimal"},
+			expected: "type Out []struct {\n\tPrice *decimal.Decimal `json:\"price\"`\n}",
+		},
+		"quoted numbers and booleans": {
+			input: `[{"count": "42", "price": "9", "ok": "true", "id": "18446744073709551616", "tags": ["1"]}, {"count": "7", "price": "9.99", "ok": "false", "id": "1"}]`,
+			opts:  Options{DetectQuoted: true, OmitStyle: OmitEmpty},
+			expected: "type Out []struct {\n" +
+				"\tCount int64 `json:\"count,string\"`\n" +
+				"\tPrice float64 `json:\"price,string\"`\n" +
+				"\tOk bool `json:\"ok,string\"`\n" +
+				"\tID string `json:\"id\"`\n" +
+				"\tTags []string `json:\"tags,omitempty\"`\n" +
+				"}",
+		},
+		"quoted numbers disagreeing with plain strings": {
+			input:    `[{"count": "42"}, {"count": "many"}, {"count": null}]`,
+			opts:     Options{DetectQuoted: true},
+			expected: "type Out []struct {\n\tCount *string `json:\"count\"`\n}",
+		},
+		"nullable quoted number": {
+			input:    `[{"count": "42"}, {"count": null}]`,
+			opts:     Options{DetectQuoted: true},
+			expected: "type Out []struct {\n\tCount *int64 `json:\"count,string\"`\n}",
+		},
+		"maps for dynamic keys": {
+			input: `{"users": {"1234": {"name": "a"}, "5678": {"name": "b", "age": 1}}, "meta": {"page": 1}}`,
+			opts:  Options{DetectMaps: true, NamedTypes: true, OmitStyle: OmitEmpty},
+			expected: "type Out struct {\n" +
+				"\tUsers map[string]OutUsersValue `json:\"users\"`\n" +
+				"\tMeta OutMeta `json:\"meta\"`\n" +
+				"}\n\n" +
+				"type OutUsersValue struct {\n" +
+				"\tName string `json:\"name\"`\n" +
+				"\tAge int `json:\"age,omitempty\"`\n" +
+				"}\n\n" +
+				"type OutMeta struct {\n" +
+				"\tPage int `json:\"page\"`\n" +
+				"}",
+		},
+		"map paths": {
+			input:    `[{"labels": {"env": "prod"}}, {"labels": null}]`,
+			opts:     Options{MapPaths: []string{"$[*].labels"}},
+			expected: "type Out []struct {\n\tLabels map[string]string `json:\"labels\"`\n}",
+		},
+		"map root": {
+			input:    `{"2024-01-01": 3, "2024-01-02": 4.5}`,
+			opts:     Options{DetectMaps: true},
+			expected: "type Out map[string]float64",
+		},
+		"mixed types": {
+			input:    `{"values": [1, "a", 2.5], "id": "x", "opt": [null, true]}`,
+			expected: "type Out struct {\n\tValues []any `json:\"values\"` // mixed types: string, number\n\tID string `json:\"id\"`\n\tOpt []*bool `json:\"opt\"`\n}",
+		},
+		"mixed types at the root": {
+			input:    `[1, "x"]`,
+			expected: "type Out []any // mixed types: string, number",
+		},
+		"only nulls at the root": {
+			input:    `[null]`,
+			expected: "type Out []any // only null values seen, type unknown",
+		},
+		"sized integers are not shared": {
+			input: `{"a": {"x": 1}, "b": {"x": 2000000000000}, "c": {"x": 2}}`,
+			opts:  Options{NamedTypes: true, SizedInts: true},
+			expected: "type Out struct {\n" +
+				"\tA OutA `json:\"a\"`\n" +
+				"\tB OutB `json:\"b\"`\n" +
+				"\tC OutA `json:\"c\"`\n" +
+				"}\n\n" +
+				"type OutA struct {\n" +
+				"\tX int `json:\"x\"`\n" +
+				"}\n\n" +
+				"type OutB struct {\n" +
+				"\tX int64 `json:\"x\"`\n" +
+				"}",
+		},
+		"union interface name is unique": {
+			input: `{"events": [{"type": "a", "x": 1}, {"type": "b", "y": 1}], "events_item_variant": {"z": 1}}`,
+			opts:  Options{Unions: true, NamedTypes: true},
+			expected: "type Out struct {\n" +
+				"\tEvents []OutEventsItem `json:\"events\"`\n" +
+				"\tEventsItemVariant OutEventsItemVariant `json:\"events_item_variant\"`\n" +
+				"}\n" +
+				"\n" +
+				"type OutEventsItem struct {\n" +
+				"\tValue OutEventsItemVariant2\n" +
+				"}\n" +
+				"\n" +
+				"// OutEventsItemVariant2 is one of OutEventsItemA, OutEventsItemB.\n" +
+				"type OutEventsItemVariant2 interface {\n" +
+				"\tisOutEventsItem()\n" +
+				"}\n" +
+				"\n" +
+				"func (u *OutEventsItem) UnmarshalJSON(data []byte) error {\n" +
+				"\tvar probe struct {\n" +
+				"\t\tType string `json:\"type\"`\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(data, &probe); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tswitch probe.Type {\n" +
+				"\tcase \"a\":\n" +
+				"\t\tu.Value = new(OutEventsItemA)\n" +
+				"\tcase \"b\":\n" +
+				"\t\tu.Value = new(OutEventsItemB)\n" +
+				"\tdefault:\n" +
+				"\t\treturn fmt.Errorf(\"unknown OutEventsItem type %q\", probe.Type)\n" +
+				"\t}\n" +
+				"\treturn json.Unmarshal(data, u.Value)\n" +
+				"}\n" +
+				"\n" +
+				"func (u OutEventsItem) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal(u.Value)\n" +
+				"}\n" +
+				"\n" +
+				"type OutEventsItemVariant struct {\n" +
+				"\tZ int `json:\"z\"`\n" +
+				"}\n" +
+				"\n" +
+				"type OutEventsItemA struct {\n" +
+				"\tType string `json:\"type\"`\n" +
+				"\tX int `json:\"x\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (OutEventsItemA) isOutEventsItem() {}\n" +
+				"\n" +
+				"type OutEventsItemB struct {\n" +
+				"\tType string `json:\"type\"`\n" +
+				"\tY int `json:\"y\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (OutEventsItemB) isOutEventsItem() {}",
+		},
+		"discriminated objects are merged without unions": {
+			input:    `[{"type": "click", "x": 1}, {"type": "view", "page": "/"}]`,
+			expected: "type Out []struct {\n\tType string `json:\"type\"`\n\tX int `json:\"x\"`\n\tPage string `json:\"page\"`\n}",
+		},
+		"unions": {
+			input: `{"events": [{"kind": "click", "x": 1}, {"kind": "view", "page": "/"}, {"kind": "click", "x": 2}]}`,
+			opts:  Options{Unions: true},
+			expected: "type Out struct {\n" +
+				"\tEvents []OutEventsItem `json:\"events\"`\n" +
+				"}\n\n" +
+				"type OutEventsItem struct {\n" +
+				"\tValue OutEventsItemVariant\n" +
+				"}\n\n" +
+				"// OutEventsItemVariant is one of OutEventsItemClick, OutEventsItemView.\n" +
+				"type OutEventsItemVariant interface {\n" +
+				"\tisOutEventsItem()\n" +
+				"}\n\n" +
+				"func (u *OutEventsItem) UnmarshalJSON(data []byte) error {\n" +
+				"\tvar probe struct {\n" +
+				"\t\tKind string `json:\"kind\"`\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(data, &probe); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tswitch probe.Kind {\n" +
+				"\tcase \"click\":\n" +
+				"\t\tu.Value = new(OutEventsItemClick)\n" +
+				"\tcase \"view\":\n" +
+				"\t\tu.Value = new(OutEventsItemView)\n" +
+				"\tdefault:\n" +
+				"\t\treturn fmt.Errorf(\"unknown OutEventsItem kind %q\", probe.Kind)\n" +
+				"\t}\n" +
+				"\treturn json.Unmarshal(data, u.Value)\n" +
+				"}\n\n" +
+				"func (u OutEventsItem) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal(u.Value)\n" +
+				"}\n\n" +
+				"type OutEventsItemClick struct {\n" +
+				"\tKind string `json:\"kind\"`\n" +
+				"\tX int `json:\"x\"`\n" +
+				"}\n\n" +
+				"func (OutEventsItemClick) isOutEventsItem() {}\n\n" +
+				"type OutEventsItemView struct {\n" +
+				"\tKind string `json:\"kind\"`\n" +
+				"\tPage string `json:\"page\"`\n" +
+				"}\n\n" +
+				"func (OutEventsItemView) isOutEventsItem() {}",
+		},
+		"unions need every object tagged": {
+			input:    `[{"type": "a", "x": 1}, {"y": 2}]`,
+			opts:     Options{Unions: true},
+			expected: "type Out []struct {\n\tType string `json:\"type\"`\n\tX int `json:\"x\"`\n\tY int `json:\"y\"`\n}",
+		},
+		"time detection": {
+			input:    `[{"at": "2024-05-01T12:00:00Z", "mixed": "2024-05-01T12:00:00Z"}, {"at": "2024-05-01T12:00:00.5+02:00", "mixed": "soon"}]`,
+			opts:     Options{DetectTime: true},
+			expected: "type Out []struct {\n\tAt time.Time `json:\"at\"`\n\tMixed string `json:\"mixed\"`\n}",
+		},
+		"time detection disabled": {
+			input:    `{"at": "2024-05-01T12:00:00Z"}`,
+			expected: "type Out struct {\n\tAt string `json:\"at\"`\n}",
+		},
+		"time layouts are not time.Time": {
+			input:    `{"day": "2000-01-31", "at": "2024-05-01T12:00:00Z"}`,
+			opts:     Options{DetectTime: true, TimeLayouts: []string{"2006-01-02"}},
+			expected: "type Out struct {\n\tDay string `json:\"day\"`\n\tAt time.Time `json:\"at\"`\n}",
+		},
+		"time layouts as a mapped date format": {
+			input: `[{"day": "2000-01-31", "at": "2024-05-01T12:00:00Z", "when": "31.01.2000"}, {"day": "31.01.2000", "when": "1/31/2000"}]`,
+			opts: Options{
+				TimeLayouts:   []string{"2006-01-02", "02.01.2006"},
+				StringFormats: map[string]string{"date": "example.com/civil.Date"},
+			},
+			expected: "type Out []struct {\n\tDay civil.Date `json:\"day\"`\n\tAt string `json:\"at\"`\n\tWhen string `json:\"when\"`\n}",
+		},
+		"time import in complete file": {
+			input: `{"at": "2024-05-01T12:00:00Z"}`,
+			opts:  Options{DetectTime: true, PackageName: "models"},
+			expected: "// Code generated by json2go. DO NOT EDIT.\n\n" +
+				"package models\n\n" +
+				"import (\n\t\"time\"\n)\n\n" +
+				"type Out struct {\n\tAt time.Time `json:\"at\"`\n}\n",
+		},
+		"sql null time in complete file": {
+			input: `[{"at": "2024-05-01T12:00:00Z"}, {"at": null}]`,
+			opts:  Options{DetectTime: true, NullStyle: NullSQL, PackageName: "models"},
+			expected: "// Code generated by json2go. DO NOT EDIT.\n\n" +
+				"package models\n\n" +
+				"import (\n\t\"database/sql\"\n)\n\n" +
+				"type Out []struct {\n\tAt sql.NullTime `json:\"at\"`\n}\n",
+		},
+		"string formats": {
+			input: `[{"id": "9b2f7c52-3a0e-4a7b-8f43-2c1d5e6f7a8b", "ip": "10.0.0.1", "site": "https://example.com", "name": "x"}, {"site": null}]`,
+			opts: Options{StringFormats: map[string]string{
+				"uuid": "github.com/google/uuid.UUID",
+				"ipv4": "net/netip.Addr",
+				"uri":  "*net/url.URL",
+			}},
+			expected: "type Out []struct {\n" +
+				"\tID uuid.UUID `json:\"id\"`\n" +
+				"\tIP netip.Addr `json:\"ip\"`\n" +
+				"\tSite *url.URL `json:\"site\"`\n" +
+				"\tName string `json:\"name\"`\n" +
+				"}",
+		},
+		"unmapped formats are not detected": {
+			input:    `{"id": "9b2f7c52-3a0e-4a7b-8f43-2c1d5e6f7a8b"}`,
+			opts:     Options{StringFormats: map[string]string{"ipv4": "net/netip.Addr"}},
+			expected: "type Out struct {\n\tID string `json:\"id\"`\n}",
+		},
+		"custom format detector": {
+			input: `{"sku": "SKU-123", "other": "x"}`,
+			opts: Options{
+				StringFormats: map[string]string{"sku": "example.com/catalog.SKU"},
+				FormatDetector: func(s string) string {
+					if strings.HasPrefix(s, "SKU-") {
+						return "sku"
+					}
+					return ""
+				},
+			},
+			expected: "type Out struct {\n\tSku catalog.SKU `json:\"sku\"`\n\tOther string `json:\"other\"`\n}",
+		},
+		"string format imports in complete file": {
+			input: `{"id": "9b2f7c52-3a0e-4a7b-8f43-2c1d5e6f7a8b", "ip": "10.0.0.1"}`,
+			opts: Options{PackageName: "models", StringFormats: map[string]string{
+				"uuid": "github.com/google/uuid.UUID",
+				"ipv4": "net/netip.Addr",
+			}},
+			expected: "// Code generated by json2go. DO NOT EDIT.\n\n" +
+				"package models\n\n" +
+				"import (\n\t\"net/netip\"\n\n\t\"github.com/google/uuid\"\n)\n\n" +
+				"type Out struct {\n" +
+				"\tID uuid.UUID  `json:\"id\"`\n" +
+				"\tIP netip.Addr `json:\"ip\"`\n" +
+				"}\n",
+		},
+		"custom initialisms": {
+			input:    `{"item_sku": "a", "eta": 1}`,
+			opts:     Options{Initialisms: []string{"sku", "ETA"}},
+			expected: "type Out struct {\n\tItemSKU string `json:\"item_sku\"`\n\tETA int `json:\"eta\"`\n}",
+		},
+		"complete file": {
+			input: `[{"id": 1, "age": null}, {"id": 2, "age": 30}]`,
+			opts:  Options{PackageName: "models", NullStyle: NullSQL},
+			expected: "// Code generated by json2go. DO NOT EDIT.\n\n" +
+				"package models\n\n" +
+				"import (\n\t\"database/sql\"\n)\n\n" +
+				"type Out []struct {\n" +
+				"\tID  int           `json:\"id\"`\n" +
+				"\tAge sql.NullInt64 `json:\"age\"`\n" +
+				"}\n",
+		},
+		"complete file without imports": {
+			input: `{"id": 1}`,
+			opts:  Options{PackageName: "models"},
+			expected: "// Code generated by json2go. DO NOT EDIT.\n\n" +
+				"package models\n\n" +
+				"type Out struct {\n" +
+				"\tID int `json:\"id\"`\n" +
+				"}\n",
+		},
+		"invalid package name": {
+			input: `{"id": 1}`,
+			opts:  Options{PackageName: "my-models"},
+			err:   ErrInvalidPackageName,
+		},
+		"invalid json": {
+			input: `{"invalid":json}`,
+			err:   ErrInvalidJSON,
+		},
+	}
+
+	for tname, tt := range tests {
+		t.Run(tname, func(t *testing.T) {
+			result, err := TransformWithOptions("Out", tt.input, tt.opts)
+			assertEqualErr(t, tt.err, err)
+			if tt.err == nil && result != tt.expected {
+				t.Errorf("wrong output\nexpected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestTransformSamples(t *testing.T) {
+	tests := map[string]struct {
+		samples  []string
+		opts     Options
+		expected string
+		err      error
+	}{
+		"fields missing in some samples are optional": {
+			samples: []string{
+				`{"id": 1, "name": "Alice", "email": null}`,
+				`{"id": 2, "email": "bob@example.com", "tags": ["admin"]}`,
+			},
+			opts: Options{OmitStyle: OmitEmpty},
+			expected: "type Out struct {\n" +
+				"\tID int `json:\"id\"`\n" +
+				"\tName string `json:\"name,omitempty\"`\n" +
+				"\tEmail *string `json:\"email\"`\n" +
+				"\tTags []string `json:\"tags,omitempty\"`\n" +
+				"}",
+		},
+		"arrays are merged with arrays": {
+			samples:  []string{`[{"id": 1}]`, `[{"id": 2, "ok": true}]`},
+			expected: "type Out []struct {\n\tID int `json:\"id\"`\n\tOk bool `json:\"ok\"`\n}",
+		},
+		"single sample": {
+			samples:  []string{`{"id": 1}`},
+			expected: "type Out struct {\n\tID int `json:\"id\"`\n}",
+		},
+		"no samples": {
+			expected: "type Out any",
+		},
+		"invalid sample": {
+			samples: []string{`{"id": 1}`, `{"id": }`},
+			err:     ErrInvalidJSON,
+		},
+	}
+
+	for tname, tt := range tests {
+		t.Run(tname, func(t *testing.T) {
+			result, err := TransformSamples("Out", tt.samples, tt.opts)
+			assertEqualErr(t, tt.err, err)
+			if tt.err == nil && result != tt.expected {
+				t.Errorf("wrong output\nexpected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestTransformWithOptions_decodesSample compiles the generated code
+// and checks that it unmarshals the sample it was generated from.
+func TestTransformWithOptions_decodesSample(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil || testing.Short() {
+		t.Skip("needs the go tool")
+	}
+
+	tests := map[string]struct {
+		input string
+		opts  Options
+		extra string // declarations used by opts
+	}{
+		"rfc 3339 time": {
+			input: `{"at": "2024-05-01T12:00:00Z"}`,
+			opts:  Options{DetectTime: true},
+		},
+		"time layouts without a mapped type": {
+			input: `{"birthday": "2000-01-31"}`,
+			opts:  Options{DetectTime: true, TimeLayouts: []string{"2006-01-02"}},
+		},
+		"union with escaped discriminators": {
+			input: `[{"type": "a\"b", "x": 1}, {"type": "\ud83d\ude00", "y": 2}, {"type": "c\/d", "z": 3}]`,
+			opts:  Options{Unions: true},
+		},
+		"time layouts with a mapped type": {
+			input: `{"birthday": "2000-01-31"}`,
+			opts: Options{
+				TimeLayouts:   []string{"2006-01-02"},
+				StringFormats: map[string]string{"date": "Date"},
+			},
+			extra: "type Date struct{ time.Time }\n\n" +
+				"func (d *Date) UnmarshalJSON(b []byte) (err error) {\n" +
+				"\td.Time, err = time.Parse(`\"2006-01-02\"`, string(b))\n" +
+				"\treturn err\n" +
+				"}\n",
+		},
+	}
+
+	for tname, tt := range tests {
+		t.Run(tname, func(t *testing.T) {
+			tt.opts.PackageName = "main"
+			code, err := TransformWithOptions("Out", tt.input, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			main := "package main\n\n" +
+				"import (\n\t\"encoding/json\"\n\t\"os\"\n\t\"time\"\n)\n\n" +
+				"var _ time.Time\n\n" + tt.extra + "\n" +
+				"func main() {\n" +
+				"\tvar out Out\n" +
+				"\tif err := json.Unmarshal([]byte(" + strconv.Quote(tt.input) + "), &out); err != nil {\n" +
+				"\t\tos.Stderr.WriteString(err.Error())\n" +
+				"\t\tos.Exit(1)\n" +
+				"\t}\n" +
+				"}\n"
+
+			dir := t.TempDir()
+			files := map[string]string{
+				"go.mod":  "module check\n\ngo 1.22\n",
+				"out.go":  code,
+				"main.go": main,
+			}
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cmd := exec.Command(goBin, "run", ".")
+			cmd.Dir = dir
+			if output, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("generated code does not decode its sample: %v\n%s\n%s", err, output, code)
+			}
+		})
+	}
+}
+
 func assertEqualErr(t *testing.T, expected, actual error) {
 	t.Helper()
 	if expected == nil && actual == nil {
@@ -0,0 +1,105 @@
+package json2go
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// defaultMapThreshold is the number of similar fields that makes an object
+// a map when [Options.MapThreshold] is not set.
+const defaultMapThreshold = 20
+
+// detectMaps walks s, located at the JSON path, and marks objects with
+// dynamic keys to be typed as maps, as configured by opts.
+//
+// Paths start at "$" for the root, followed by ".key" for object fields,
+// "[*]" for array elements and ".*" for map values.
+func detectMaps(s *shape, path string, opts *Options) {
+	forced := slices.Contains(opts.MapPaths, path)
+	if s.kinds.has(ObjectValue) && (forced || opts.DetectMaps && hasDynamicKeys(s, opts.MapThreshold)) {
+		s.mapValue = &shape{}
+		for _, f := range s.fields {
+			s.mapValue.merge(f.shape)
+		}
+		detectMaps(s.mapValue, path+".*", opts)
+	} else {
+		for _, f := range s.fields {
+			detectMaps(f.shape, path+"."+f.key, opts)
+		}
+		for _, value := range s.variantOrder {
+			detectMaps(s.variants[value], path, opts)
+		}
+	}
+
+	if s.elem != nil {
+		detectMaps(s.elem, path+"[*]", opts)
+	}
+}
+
+// hasDynamicKeys reports whether the fields of s look like map entries:
+// every key is an ID, a date or a hash, or there are at least threshold
+// fields whose values are all alike.
+func hasDynamicKeys(s *shape, threshold int) bool {
+	if len(s.fields) == 0 {
+		return false
+	}
+
+	dynamic := true
+	for _, f := range s.fields {
+		if !isDynamicKey(f.key) {
+			dynamic = false
+			break
+		}
+	}
+	if dynamic {
+		return true
+	}
+
+	if threshold <= 0 {
+		threshold = defaultMapThreshold
+	}
+	if len(s.fields) < threshold {
+		return false
+	}
+	for _, f := range s.fields[1:] {
+		if !alike(s.fields[0].shape, f.shape) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDynamicKey reports whether an object key is data rather than a name:
+// a number, a UUID, a date or a hex encoded hash.
+func isDynamicKey(key string) bool {
+	if _, err := time.Parse(time.DateOnly, key); err == nil {
+		return true
+	}
+	if _, err := time.Parse(time.RFC3339, key); err == nil {
+		return true
+	}
+	if isUUID(key) {
+		return true
+	}
+
+	trimmed := strings.TrimPrefix(key, "-")
+	if trimmed != "" && strings.IndexFunc(trimmed, func(r rune) bool { return r < '0' || r > '9' }) < 0 {
+		return true
+	}
+	return len(key) >= 16 && strings.IndexFunc(key, func(r rune) bool { return !unicode.Is(unicode.ASCII_Hex_Digit, r) }) < 0
+}
+
+// alike reports whether two shapes have the same kinds and, for objects, the same keys.
+func alike(a, b *shape) bool {
+	if a.kinds != b.kinds || len(a.fields) != len(b.fields) {
+		return false
+	}
+	for _, f := range a.fields {
+		if _, ok := b.index[f.key]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package json2go
+
+import "testing"
+
+func TestIsDynamicKey(t *testing.T) {
+	tests := map[string]bool{
+		"1234":                                 true,
+		"-1":                                   true,
+		"2024-05-01":                           true,
+		"2024-05-01T12:00:00Z":                 true,
+		"9b2f7c52-3a0e-4a7b-8f43-2c1d5e6f7a8b": true,
+		"8e4f1b2a9c3d7e6f":                     true,
+		"name":                                 false,
+		"user_1":                               false,
+		"deadbeef":                             false,
+		"-":                                    false,
+		"":                                     false,
+	}
+	for key, expected := range tests {
+		t.Run(key, func(t *testing.T) {
+			if got := isDynamicKey(key); got != expected {
+				t.Errorf("isDynamicKey(%q): expected %v, got %v", key, expected, got)
+			}
+		})
+	}
+}
+
+func TestDetectMaps(t *testing.T) {
+	parse := func(t *testing.T, input string) *shape {
+		t.Helper()
+		v, err := NewParser(NewLexer([]byte(input))).Parse()
+		if err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		return infer(v, inferConfig{})
+	}
+
+	t.Run("dynamic keys merge values", func(t *testing.T) {
+		s := parse(t, `{"1": {"a": 1}, "2": {"b": "x"}}`)
+		detectMaps(s, "$", &Options{DetectMaps: true})
+		if s.mapValue == nil {
+			t.Fatalf("expected a map")
+		}
+		if len(s.mapValue.fields) != 2 || s.mapValue.objects != 2 {
+			t.Errorf("expected merged value with 2 fields from 2 objects, got %d fields from %d objects",
+				len(s.mapValue.fields), s.mapValue.objects)
+		}
+	})
+
+	t.Run("threshold of alike fields", func(t *testing.T) {
+		s := parse(t, `{"en": "hi", "de": "hallo", "uk": "привіт"}`)
+		detectMaps(s, "$", &Options{DetectMaps: true, MapThreshold: 4})
+		if s.mapValue != nil {
+			t.Errorf("expected a struct below the threshold")
+		}
+		detectMaps(s, "$", &Options{DetectMaps: true, MapThreshold: 3})
+		if s.mapValue == nil {
+			t.Errorf("expected a map at the threshold")
+		}
+	})
+
+	t.Run("unlike fields are not a map", func(t *testing.T) {
+		s := parse(t, `{"name": "a", "age": 1, "admin": true}`)
+		detectMaps(s, "$", &Options{DetectMaps: true, MapThreshold: 2})
+		if s.mapValue != nil {
+			t.Errorf("expected a struct")
+		}
+	})
+
+	t.Run("forced path", func(t *testing.T) {
+		s := parse(t, `{"items": [{"labels": {"env": "prod"}}]}`)
+		detectMaps(s, "$", &Options{MapPaths: []string{"$.items[*].labels"}})
+		if s.fields[0].shape.elem.fields[0].shape.mapValue == nil {
+			t.Errorf("expected labels to be a map")
+		}
+		if s.mapValue != nil {
+			t.Errorf("expected root to be a struct")
+		}
+	})
+}
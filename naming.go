@@ -0,0 +1,134 @@
+package json2go
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// commonInitialisms are written in all caps in field and type names, see
+// https://go.dev/wiki/CodeReviewComments#initialisms
+var commonInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP",
+	"HTTPS", "ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA",
+	"SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID",
+	"URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+// goName converts a json key to an exported Go identifier,
+// e.g. "user_id", "user-id", "user id" and "userId" all become "UserID".
+// Words found in initialisms are written in all caps.
+func goName(jsonKey string, initialisms map[string]bool) string {
+	var result strings.Builder
+	result.Grow(len(jsonKey) + 1)
+
+	for _, word := range splitWords(jsonKey) {
+		upper := strings.ToUpper(word)
+		letters := strings.TrimRightFunc(upper, unicode.IsDigit)
+		switch {
+		case initialisms[upper], initialisms[letters]: // letters, e.g. id2 -> ID2
+			result.WriteString(upper)
+		case upper == word: // all caps words are not shouted, e.g. NAME -> Name
+			r, size := utf8.DecodeRuneInString(word)
+			result.WriteRune(r)
+			result.WriteString(strings.ToLower(word[size:]))
+		default:
+			r, size := utf8.DecodeRuneInString(word)
+			result.WriteRune(unicode.ToUpper(r))
+			result.WriteString(word[size:])
+		}
+	}
+
+	name := result.String()
+	if name == "" {
+		return "Field"
+	}
+	if r, _ := utf8.DecodeRuneInString(name); !unicode.IsUpper(r) {
+		return "F" + name // starts with a digit, or a letter without case
+	}
+	return name
+}
+
+// initialismSet returns the common initialisms together with extra ones.
+func initialismSet(extra []string) map[string]bool {
+	set := make(map[string]bool, len(commonInitialisms)+len(extra))
+	for _, word := range commonInitialisms {
+		set[word] = true
+	}
+	for _, word := range extra {
+		set[strings.ToUpper(word)] = true
+	}
+	return set
+}
+
+// uniqueNames renames, in place, every repeated name after its first
+// occurrence by appending the smallest numeric suffix that is not taken
+// by any other name, e.g. "UserID", "UserID" becomes "UserID", "UserID2".
+func uniqueNames(names []string) {
+	taken := make(map[string]bool, len(names))
+	dup := make([]bool, len(names))
+	for i, name := range names {
+		dup[i] = taken[name]
+		taken[name] = true
+	}
+
+	for i, name := range names {
+		if !dup[i] {
+			continue
+		}
+		unique := name
+		for n := 2; taken[unique]; n++ {
+			unique = name + strconv.Itoa(n)
+		}
+		taken[unique] = true
+		names[i] = unique
+	}
+}
+
+// splitWords splits s into words. Any rune other than a letter or digit
+// separates words, and so do camelCase humps and digits followed by letters:
+// "HTTPStatus_code2fa" is split into "HTTP", "Status", "code2", "fa".
+func splitWords(s string) []string {
+	var words []string
+	start := -1
+	var prev rune
+	for i, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, s[start:i])
+				start = -1
+			}
+			continue
+		}
+
+		if start >= 0 && isWordBoundary(prev, r, s[i+utf8.RuneLen(r):]) {
+			words = append(words, s[start:i])
+			start = -1
+		}
+		if start < 0 {
+			start = i
+		}
+		prev = r
+	}
+	if start >= 0 {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+// isWordBoundary reports whether a new word starts at cur,
+// given the rune before it and the rest of the input after it.
+func isWordBoundary(prev, cur rune, rest string) bool {
+	switch {
+	case unicode.IsDigit(prev) && unicode.IsLetter(cur): // code2fa
+		return true
+	case unicode.IsLower(prev) && unicode.IsUpper(cur): // userId
+		return true
+	case unicode.IsUpper(prev) && unicode.IsUpper(cur): // HTTPStatus
+		next, _ := utf8.DecodeRuneInString(rest)
+		return unicode.IsLower(next)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+package json2go
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoName(t *testing.T) {
+	initialisms := map[string]bool{}
+	for _, word := range commonInitialisms {
+		initialisms[word] = true
+	}
+
+	tests := map[string]string{
+		"name":          "Name",
+		"first_name":    "FirstName",
+		"first-name":    "FirstName",
+		"first name":    "FirstName",
+		"first.name":    "FirstName",
+		"firstName":     "FirstName",
+		"FirstName":     "FirstName",
+		"FIRST_NAME":    "FirstName",
+		"user_id":       "UserID",
+		"userId":        "UserID",
+		"userID":        "UserID",
+		"avatar_url":    "AvatarURL",
+		"HTTPStatus":    "HTTPStatus",
+		"http-status":   "HTTPStatus",
+		"utf8":          "UTF8",
+		"id2":           "ID2",
+		"address2":      "Address2",
+		"code2fa":       "Code2Fa",
+		"__private":     "Private",
+		"$ref":          "Ref",
+		"@type":         "Type",
+		"1st":           "F1St",
+		"123":           "F123",
+		"":              "Field",
+		"_":             "Field",
+		"-":             "Field",
+		"имя":           "Имя",
+		"你好":            "F你好",
+		"a.b.c":         "ABC",
+		"x-api-version": "XAPIVersion",
+	}
+	for key, expected := range tests {
+		t.Run(key, func(t *testing.T) {
+			if got := goName(key, initialisms); got != expected {
+				t.Errorf("goName(%q): expected %q, got %q", key, expected, got)
+			}
+		})
+	}
+}
+
+func TestSplitWords(t *testing.T) {
+	tests := map[string][]string{
+		"HTTPStatus_code2fa": {"HTTP", "Status", "code2", "fa"},
+		"first name":         {"first", "name"},
+		"a--b":               {"a", "b"},
+		"camelCaseKey":       {"camel", "Case", "Key"},
+		"":                   nil,
+	}
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			if got := splitWords(input); !reflect.DeepEqual(got, expected) {
+				t.Errorf("splitWords(%q): expected %q, got %q", input, expected, got)
+			}
+		})
+	}
+}
+
+func TestUniqueNames(t *testing.T) {
+	tests := map[string]struct {
+		names    []string
+		expected []string
+	}{
+		"no collisions": {
+			names:    []string{"A", "B"},
+			expected: []string{"A", "B"},
+		},
+		"collision": {
+			names:    []string{"UserID", "UserID", "UserID"},
+			expected: []string{"UserID", "UserID2", "UserID3"},
+		},
+		"suffix taken by another name": {
+			names:    []string{"A", "A", "A2"},
+			expected: []string{"A", "A3", "A2"},
+		},
+	}
+	for tname, tt := range tests {
+		t.Run(tname, func(t *testing.T) {
+			uniqueNames(tt.names)
+			if !reflect.DeepEqual(tt.names, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, tt.names)
+			}
+		})
+	}
+}
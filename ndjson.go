@@ -0,0 +1,43 @@
+package json2go
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// ndjsonSamples yields the value on each line of r, newline-delimited json,
+// parsing one line at a time. Blank lines are skipped, and parse errors
+// report the line they occurred on.
+func ndjsonSamples(r io.Reader) iter.Seq2[Value, error] {
+	return func(yield func(Value, error) bool) {
+		br := bufio.NewReaderSize(r, 64*1024)
+		for n := 1; ; n++ {
+			// every line is read into a new buffer, since parsed
+			// strings and keys refer to it.
+			line, err := br.ReadBytes('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				yield(Value{}, err)
+				return
+			}
+
+			if len(bytes.TrimSpace(line)) > 0 {
+				v, perr := NewParser(NewLexer(line)).Parse()
+				if perr != nil {
+					yield(Value{}, fmt.Errorf("line %d: %w", n, errors.Join(ErrInvalidJSON, perr)))
+					return
+				}
+				if !yield(v, nil) {
+					return
+				}
+			}
+
+			if err != nil { // io.EOF
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package json2go
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestTransformNDJSON(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		opts     Options
+		expected string
+		err      error
+	}{
+		"records are merged": {
+			input: `{"level": "info", "msg": "started", "port": 8080}` + "\n" +
+				`{"level": "error", "msg": "failed", "err": "timeout"}` + "\n",
+			opts: Options{OmitStyle: OmitEmpty},
+			expected: "type Out struct {\n" +
+				"\tLevel string `json:\"level\"`\n" +
+				"\tMsg string `json:\"msg\"`\n" +
+				"\tPort int `json:\"port,omitempty\"`\n" +
+				"\tErr string `json:\"err,omitempty\"`\n" +
+				"}",
+		},
+		"blank lines, crlf and no final newline": {
+			input:    "{\"id\": 1}\r\n\r\n  \n{\"id\": null}",
+			expected: "type Out struct {\n\tID *int `json:\"id\"`\n}",
+		},
+		"empty input": {
+			expected: "type Out any",
+		},
+		"invalid line": {
+			input: "{\"id\": 1}\n{\"id\": }\n",
+			err:   ErrInvalidJSON,
+		},
+		"several values on a line": {
+			input: `{"id": 1} {"id": 2}`,
+			err:   ErrInvalidJSON,
+		},
+	}
+
+	for tname, tt := range tests {
+		t.Run(tname, func(t *testing.T) {
+			result, err := TransformNDJSON("Out", strings.NewReader(tt.input), tt.opts)
+			assertEqualErr(t, tt.err, err)
+			if tt.err == nil && result != tt.expected {
+				t.Errorf("wrong output\nexpected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestTransformNDJSON_errors(t *testing.T) {
+	_, err := TransformNDJSON("Out", strings.NewReader("{\"id\": 1}\n\n{\"id\": }\n"), Options{})
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3: ") {
+		t.Errorf("expected an error on line 3, got: %v", err)
+	}
+
+	readErr := errors.New("read failed")
+	_, err = TransformNDJSON("Out", iotest.ErrReader(readErr), Options{})
+	assertEqualErr(t, readErr, err)
+
+	_, err = TransformNDJSON("1Out", strings.NewReader(`{"id": 1}`), Options{})
+	assertEqualErr(t, ErrInvalidStructName, err)
+}
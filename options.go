@@ -0,0 +1,174 @@
+package json2go
+
+// Options configures the Go code generated by [TransformWithOptions] and [Transpiler].
+// The zero value produces the same output as [Transform] with json tags.
+type Options struct {
+	// OmitTags disables struct tags on fields.
+	OmitTags bool
+
+	// Tags lists the struct tag keys written on every field, in order,
+	// e.g. []string{"json", "yaml"}. Empty keys are ignored. Defaults to "json".
+	Tags []string
+
+	// OmitStyle selects the json tag option added to fields that
+	// are missing from at least one of the merged objects.
+	OmitStyle OmitStyle
+
+	// Initialisms lists extra words, e.g. "SKU", written in all caps in field
+	// and type names, in addition to the common Go initialisms like ID and URL.
+	Initialisms []string
+
+	// PackageName, when set, makes the output a complete gofmt'ed Go source file:
+	// a generated code header, the package clause and the imports used by the types.
+	PackageName string
+
+	// DetectMaps types objects with dynamic keys as map[string]T, where T merges
+	// all of their values. Keys are dynamic when all of them are numbers, UUIDs,
+	// dates or hex hashes, or when there are at least MapThreshold keys whose
+	// values have the same kind and fields.
+	DetectMaps bool
+
+	// MapThreshold is the number of alike fields that makes DetectMaps type
+	// an object as a map. Defaults to 20.
+	MapThreshold int
+
+	// MapPaths lists JSON paths of objects that are always typed as maps.
+	// Paths start at "$" for the root value, followed by ".key" for object
+	// fields, "[*]" for array elements and ".*" for map values,
+	// e.g. "$.users" or "$.items[*].attributes".
+	MapPaths []string
+
+	// NamedTypes hoists every nested object into its own type declaration,
+	// named after its parent type and field, instead of an anonymous struct.
+	//
+	// Objects with identical field sets share a single declaration, named
+	// after the first occurrence: outermost first, then in field order.
+	NamedTypes bool
+
+	// NumberType, when set, is the type of every numeric field, e.g.
+	// "encoding/json.Number" or "github.com/shopspring/decimal.Decimal",
+	// written as in StringFormats. It overrides all other numeric options.
+	NumberType string
+
+	// SizedInts types integers as int64 when any sample is outside
+	// the int32 range, instead of int.
+	SizedInts bool
+
+	// UnsignedInts types integers as uint64 when no sample is negative.
+	UnsignedInts bool
+
+	// BigIntStyle selects the type of integers that overflow int64.
+	BigIntStyle BigIntStyle
+
+	// DetectQuoted types fields whose every sample is a string holding a number
+	// or a boolean, like "42", "9.99" or "true", as int64, float64 or bool with
+	// the ,string json tag option. Array elements are left as strings.
+	DetectQuoted bool
+
+	// DetectTime types strings as time.Time when every sample is an
+	// RFC 3339 timestamp, the only layout time.Time unmarshals from,
+	// falling back to string otherwise.
+	DetectTime bool
+
+	// StringFormats maps string formats to the Go types used for strings
+	// whose every sample has that format, e.g. "uuid": "github.com/google/uuid.UUID".
+	// A type is written as an optional import path followed by the type name,
+	// like "*net/url.URL", "net/netip.Addr" or "[]byte".
+	//
+	// Detected formats are "date-time" (RFC 3339), "date" (see TimeLayouts),
+	// "uuid", "ipv4", "ipv6", "email", "uri", "duration" (as in
+	// [time.ParseDuration]) and "byte" (standard base64), plus the ones
+	// reported by FormatDetector. Only mapped formats are detected.
+	StringFormats map[string]string
+
+	// FormatDetector, if set, is consulted before the built-in formats and
+	// returns the format of a string value, or "" if it has none.
+	FormatDetector func(s string) string
+
+	// TimeLayouts lists the [time.Parse] layouts of strings with the "date"
+	// format, typed only if StringFormats maps it to a type that unmarshals
+	// them, since time.Time does not. Defaults to "2006-01-02".
+	// RFC 3339 strings have the "date-time" format instead.
+	TimeLayouts []string
+
+	// NullStyle selects how values that are null in only some samples are typed.
+	// Arrays are never wrapped, a nil slice already represents null.
+	NullStyle NullStyle
+
+	// Unions types objects that are told apart by a discriminator field, like
+	// {"type": "click", ...} and {"type": "view", ...}, as a struct holding
+	// one of several variant structs, with a custom UnmarshalJSON that
+	// dispatches on the discriminator. Without it, fields of every variant
+	// are merged into a single struct.
+	Unions bool
+
+	// Discriminators lists the keys, in order of preference, whose string
+	// value selects the variant of an object when Unions is set.
+	// Defaults to "type", "kind" and "__typename".
+	Discriminators []string
+}
+
+// defaultDiscriminators are the discriminator keys used when
+// [Options.Discriminators] is not set.
+var defaultDiscriminators = []string{"type", "kind", "__typename"}
+
+// discriminatorKeys returns the keys splitting objects into variants,
+// or nil if Unions is not set.
+func (o *Options) discriminatorKeys() []string {
+	switch {
+	case !o.Unions:
+		return nil
+	case len(o.Discriminators) == 0:
+		return defaultDiscriminators
+	default:
+		return o.Discriminators
+	}
+}
+
+// NullStyle selects how a value that is null in some samples,
+// but not in all of them, is typed.
+type NullStyle int
+
+const (
+	// NullPointer uses a pointer, e.g. *int.
+	NullPointer NullStyle = iota
+
+	// NullSQL uses the database/sql null types, e.g. sql.NullInt64,
+	// falling back to a pointer for objects.
+	// Note that these types do not implement [encoding/json.Unmarshaler].
+	NullSQL
+
+	// NullOptional wraps the type in a generic Optional[T],
+	// which is expected to be provided by the caller's package.
+	NullOptional
+)
+
+// BigIntStyle selects the type of integers that overflow int64,
+// which would lose precision as float64.
+type BigIntStyle int
+
+const (
+	// BigIntNumber uses [encoding/json.Number], a string type holding the literal.
+	BigIntNumber BigIntStyle = iota
+
+	// BigIntUint64 uses uint64 when no sample is negative or overflows it,
+	// falling back to [encoding/json.Number].
+	BigIntUint64
+
+	// BigIntBig uses *[math/big.Int].
+	BigIntBig
+)
+
+// OmitStyle selects the json tag option written on optional fields.
+type OmitStyle int
+
+const (
+	// OmitNone writes no tag option.
+	OmitNone OmitStyle = iota
+
+	// OmitEmpty writes `json:"key,omitempty"`.
+	OmitEmpty
+
+	// OmitZero writes `json:"key,omitzero"`, supported since Go 1.24.
+	OmitZero
+)
@@ -1,6 +1,7 @@
 package json2go
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 )
@@ -47,13 +48,13 @@ func (p *Parser) parseValue() (Value, error) {
 		return v, nil
 	case NUMBER:
 		n, err := strconv.ParseInt(p.cur.Literal, 10, 64)
-		if err != nil {
-			f, ferr := strconv.ParseFloat(p.cur.Literal, 64)
-			if ferr != nil {
-				return Value{}, fmt.Errorf("invalid number: %w", err)
-			}
+		if errors.Is(err, strconv.ErrRange) { // keep big integers exact
+			v := Value{Kind: NumberValue, Raw: p.cur.Literal}
 			p.advance()
-			return Value{Kind: DecimalValue, Float: f}, nil
+			return v, nil
+		}
+		if err != nil {
+			return Value{}, fmt.Errorf("invalid number: %w", err)
 		}
 		p.advance()
 		return Value{Kind: NumberValue, Int: n}, nil
@@ -18,12 +18,20 @@ func TestParser_Parse(t *testing.T) {
 		},
 		"integer value":    {inp: `42`, expected: Value{Kind: NumberValue, Int: 42}},
 		"negative integer": {inp: `-42`, expected: Value{Kind: NumberValue, Int: -42}},
-		"decimal value":    {inp: `3.14`, expected: Value{Kind: DecimalValue, Float: 3.14}},
-		"bool true":        {inp: `true`, expected: Value{Kind: BoolValue, Bool: true}},
-		"bool false":       {inp: `false`, expected: Value{Kind: BoolValue, Bool: false}},
-		"null":             {inp: `null`, expected: Value{Kind: NullValue}},
-		"empty object":     {inp: `{}`, expected: Value{Kind: ObjectValue}},
-		"empty array":      {inp: `[]`, expected: Value{Kind: ArrayValue}},
+		"big integer": {
+			inp:      `18446744073709551615`,
+			expected: Value{Kind: NumberValue, Raw: "18446744073709551615"},
+		},
+		"big negative integer": {
+			inp:      `-9223372036854775809`,
+			expected: Value{Kind: NumberValue, Raw: "-9223372036854775809"},
+		},
+		"decimal value": {inp: `3.14`, expected: Value{Kind: DecimalValue, Float: 3.14}},
+		"bool true":     {inp: `true`, expected: Value{Kind: BoolValue, Bool: true}},
+		"bool false":    {inp: `false`, expected: Value{Kind: BoolValue, Bool: false}},
+		"null":          {inp: `null`, expected: Value{Kind: NullValue}},
+		"empty object":  {inp: `{}`, expected: Value{Kind: ObjectValue}},
+		"empty array":   {inp: `[]`, expected: Value{Kind: ArrayValue}},
 		"flat object": {
 			inp: `{"name": "John", "age": 30, "active": true}`,
 			expected: Value{Kind: ObjectValue, Object: []Field{
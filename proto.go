@@ -0,0 +1,315 @@
+package json2go
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ProtoGenerator generates Protocol Buffers (proto3) message definitions from
+// AST [Value], inferring types the same way as [Transpiler]. Nested objects
+// are declared as messages nested in their parent, and field names are
+// snake_case, numbered in the order they were first seen.
+//
+// Options specific to Go, like tags, NumberType or NullStyle, are ignored,
+// and so are Unions. PackageName sets the proto package.
+// A ProtoGenerator is not safe for concurrent use.
+type ProtoGenerator struct {
+	Options
+
+	initialisms map[string]bool // upper cased words kept in all caps
+	imports     map[string]bool // proto files of the well-known types used
+}
+
+// NewProtoGenerator returns a [ProtoGenerator] configured by opts.
+func NewProtoGenerator(opts Options) *ProtoGenerator {
+	return &ProtoGenerator{Options: opts}
+}
+
+// protoScalars are the types whose presence is tracked with the optional label.
+var protoScalars = map[string]bool{
+	"bool": true, "string": true, "double": true,
+	"int32": true, "int64": true, "uint32": true, "uint64": true,
+}
+
+// Generate converts a [Value] AST to a .proto file with a message of the given name.
+//
+// A root that is not an object is wrapped into a message, with the
+// field "items" for arrays and "value" for anything else. RFC 3339 strings
+// are typed as google.protobuf.Timestamp, values of conflicting or unknown
+// kinds as google.protobuf.Value, and scalars null or missing in some
+// samples are optional.
+func (g *ProtoGenerator) Generate(name string, v Value) (string, error) {
+	if !isProtoIdentifier(name) {
+		return "", ErrInvalidStructName
+	}
+	if g.PackageName != "" && !isValidProtoPackage(g.PackageName) {
+		return "", ErrInvalidPackageName
+	}
+
+	g.initialisms = initialismSet(g.Initialisms)
+	g.imports = make(map[string]bool)
+
+	root := infer(v, inferConfig{format: g.stringFormat})
+	detectMaps(root, "$", &g.Options)
+	if root.kinds != 1<<ObjectValue || root.mapValue != nil {
+		key := "value"
+		if root.kinds == 1<<ArrayValue {
+			key = "items"
+		}
+		wrapper := &shape{kinds: 1 << ObjectValue, objects: 1}
+		field := wrapper.field(key)
+		field.count, field.shape = 1, root
+		root = wrapper
+	}
+
+	var body strings.Builder
+	g.writeMessage(&body, name, root, 0)
+
+	var buf strings.Builder
+	buf.WriteString("syntax = \"proto3\";\n\n")
+	if g.PackageName != "" {
+		buf.WriteString("package ")
+		buf.WriteString(g.PackageName)
+		buf.WriteString(";\n\n")
+	}
+	if len(g.imports) > 0 {
+		for _, path := range slices.Sorted(maps.Keys(g.imports)) {
+			buf.WriteString("import \"")
+			buf.WriteString(path)
+			buf.WriteString("\";\n")
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(body.String())
+	return buf.String(), nil
+}
+
+// protoField is a field of a message being written.
+type protoField struct {
+	label    string // "repeated ", "optional " or ""
+	typ      string
+	name     string
+	jsonName string // set if the json key does not match name
+}
+
+// writeMessage writes a message with the fields of s, preceded by
+// the definitions of the messages nested in it.
+func (g *ProtoGenerator) writeMessage(buf *strings.Builder, name string, s *shape, depth int) {
+	names := make([]string, len(s.fields))
+	for i, f := range s.fields {
+		names[i] = snakeName(f.key)
+	}
+	uniqueNames(names)
+
+	taken := make(map[string]bool) // names of nested messages
+	var nested []namedShape
+	fields := make([]protoField, len(s.fields))
+	for i, f := range s.fields {
+		typ, repeated, msg := g.fieldType(goName(f.key, g.initialisms), f.shape)
+		if msg != nil {
+			base := msg.name
+			for n := 2; taken[msg.name]; n++ {
+				msg.name = base + strconv.Itoa(n)
+			}
+			taken[msg.name] = true
+			typ = strings.Replace(typ, base, msg.name, 1)
+			nested = append(nested, *msg)
+		}
+
+		field := protoField{typ: typ, name: names[i]}
+		switch {
+		case repeated:
+			field.label = "repeated "
+		case protoScalars[typ] && (f.shape.null || f.count < s.objects):
+			field.label = "optional "
+		}
+		if f.key != names[i] && f.key != protoJSONName(names[i]) {
+			field.jsonName = f.key
+		}
+		fields[i] = field
+	}
+
+	writeProtoIndent(buf, depth)
+	buf.WriteString("message ")
+	buf.WriteString(name)
+	buf.WriteString(" {\n")
+	for _, msg := range nested {
+		g.writeMessage(buf, msg.name, msg.shape, depth+1)
+		buf.WriteString("\n\n")
+	}
+	for i, f := range fields {
+		writeProtoIndent(buf, depth+1)
+		buf.WriteString(f.label)
+		buf.WriteString(f.typ)
+		buf.WriteByte(' ')
+		buf.WriteString(f.name)
+		buf.WriteString(" = ")
+		buf.WriteString(strconv.Itoa(i + 1))
+		if f.jsonName != "" {
+			buf.WriteString(` [json_name = "`)
+			buf.WriteString(f.jsonName)
+			buf.WriteString(`"]`)
+		}
+		buf.WriteString(";\n")
+	}
+	writeProtoIndent(buf, depth)
+	buf.WriteByte('}')
+}
+
+// fieldType returns the type of a field holding values of shape s, whether it
+// is repeated, and the message to nest for it, if any, named after name.
+func (g *ProtoGenerator) fieldType(name string, s *shape) (typ string, repeated bool, msg *namedShape) {
+	kind, ok := s.kind()
+	if !ok {
+		return g.wellKnown("google.protobuf.Value"), false, nil
+	}
+
+	switch kind {
+	case ArrayValue:
+		typ, repeated, msg = g.fieldType(name+"Item", s.elem)
+		switch {
+		case repeated: // arrays of arrays
+			return g.wellKnown("google.protobuf.ListValue"), true, nil
+		case strings.HasPrefix(typ, "map<"): // arrays of maps
+			return g.wellKnown("google.protobuf.Struct"), true, nil
+		}
+		return typ, true, msg
+
+	case ObjectValue:
+		switch {
+		case s.mapValue != nil:
+			typ, repeated, msg = g.fieldType(name+"Value", s.mapValue)
+			switch {
+			case repeated:
+				typ, msg = g.wellKnown("google.protobuf.ListValue"), nil
+			case strings.HasPrefix(typ, "map<"):
+				typ, msg = g.wellKnown("google.protobuf.Struct"), nil
+			}
+			return "map<string, " + typ + ">", false, msg
+		case len(s.fields) == 0:
+			return g.wellKnown("google.protobuf.Struct"), false, nil
+		default:
+			return name, false, &namedShape{name: name, shape: s}
+		}
+
+	case StringValue:
+		if s.format == "date-time" {
+			return g.wellKnown("google.protobuf.Timestamp"), false, nil
+		}
+		return "string", false, nil
+	case NumberValue:
+		return g.intType(s), false, nil
+	case DecimalValue:
+		return "double", false, nil
+	default:
+		return "bool", false, nil
+	}
+}
+
+// intType is the type of integers, as narrow as SizedInts and UnsignedInts allow.
+// Integers that overflow uint64 lose precision as doubles.
+func (g *ProtoGenerator) intType(s *shape) string {
+	switch {
+	case s.bigInts > 0 && (s.overUint64 || s.minInt < 0):
+		return "double"
+	case s.bigInts > 0:
+		return "uint64"
+	case g.UnsignedInts && s.minInt >= 0:
+		if g.SizedInts && s.maxInt <= math.MaxUint32 {
+			return "uint32"
+		}
+		return "uint64"
+	case g.SizedInts && s.fitsInt32():
+		return "int32"
+	default:
+		return "int64"
+	}
+}
+
+// wellKnown records the import of a well-known type and returns its name.
+func (g *ProtoGenerator) wellKnown(typ string) string {
+	switch typ {
+	case "google.protobuf.Timestamp":
+		g.imports["google/protobuf/timestamp.proto"] = true
+	default: // Value, ListValue and Struct
+		g.imports["google/protobuf/struct.proto"] = true
+	}
+	return typ
+}
+
+// stringFormat classifies RFC 3339 strings for [infer] as "date-time",
+// the only format google.protobuf.Timestamp accepts in json.
+func (g *ProtoGenerator) stringFormat(s string) string {
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return "date-time"
+	}
+	return ""
+}
+
+func writeProtoIndent(buf *strings.Builder, depth int) {
+	for i := 0; i < depth; i++ {
+		buf.WriteString("  ")
+	}
+}
+
+// snakeName converts a json key to a snake_case field name, e.g. "userID" -> "user_id".
+func snakeName(key string) string {
+	words := splitWords(key)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+
+	name := strings.Join(words, "_")
+	if name == "" {
+		return "field"
+	}
+	if name[0] < 'a' || name[0] > 'z' {
+		return "f_" + name // starts with a digit, or a letter outside ASCII
+	}
+	return name
+}
+
+// protoJSONName returns the lowerCamelCase json name protoc derives from a field name.
+func protoJSONName(name string) string {
+	var buf strings.Builder
+	upper := false
+	for _, r := range name {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			buf.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+// isValidProtoPackage reports whether s is a dot separated list of identifiers, like "api.v1".
+func isValidProtoPackage(s string) bool {
+	for part := range strings.SplitSeq(s, ".") {
+		if !isProtoIdentifier(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// isProtoIdentifier reports whether s is an ASCII letter or underscore,
+// followed by ASCII letters, digits and underscores.
+func isProtoIdentifier(s string) bool {
+	for i, c := range []byte(s) {
+		letter := c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+		if !letter && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
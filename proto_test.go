@@ -0,0 +1,115 @@
+package json2go
+
+import "testing"
+
+func TestTransformToProto(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		opts     Options
+		expected string
+		err      error
+	}{
+		"scalars and names": {
+			input: `{"id": 1, "userName": "a", "price": 9.99, "in-stock": true}`,
+			opts:  Options{PackageName: "shop.v1"},
+			expected: "syntax = \"proto3\";\n\n" +
+				"package shop.v1;\n\n" +
+				"message Out {\n" +
+				"  int64 id = 1;\n" +
+				"  string user_name = 2;\n" +
+				"  double price = 3;\n" +
+				"  bool in_stock = 4 [json_name = \"in-stock\"];\n" +
+				"}",
+		},
+		"nested and repeated messages": {
+			input: `{"owner": {"name": "a", "address": {"city": "b"}}, "items": [{"sku": "x", "qty": 1}, {"sku": "y"}], "tags": ["a"]}`,
+			expected: "syntax = \"proto3\";\n\n" +
+				"message Out {\n" +
+				"  message Owner {\n" +
+				"    message Address {\n" +
+				"      string city = 1;\n" +
+				"    }\n\n" +
+				"    string name = 1;\n" +
+				"    Address address = 2;\n" +
+				"  }\n\n" +
+				"  message ItemsItem {\n" +
+				"    string sku = 1;\n" +
+				"    optional int64 qty = 2;\n" +
+				"  }\n\n" +
+				"  Owner owner = 1;\n" +
+				"  repeated ItemsItem items = 2;\n" +
+				"  repeated string tags = 3;\n" +
+				"}",
+		},
+		"well-known types": {
+			input: `{"created_at": "2024-05-01T12:00:00Z", "any": [1, "a"], "meta": {}, "grid": [[1]]}`,
+			expected: "syntax = \"proto3\";\n\n" +
+				"import \"google/protobuf/struct.proto\";\n" +
+				"import \"google/protobuf/timestamp.proto\";\n\n" +
+				"message Out {\n" +
+				"  google.protobuf.Timestamp created_at = 1;\n" +
+				"  repeated google.protobuf.Value any = 2;\n" +
+				"  google.protobuf.Struct meta = 3;\n" +
+				"  repeated google.protobuf.ListValue grid = 4;\n" +
+				"}",
+		},
+		"maps and sized ints": {
+			input: `{"stock": {"1234": 5, "5678": 7}, "big": 5000000000, "delta": -1}`,
+			opts:  Options{DetectMaps: true, SizedInts: true},
+			expected: "syntax = \"proto3\";\n\n" +
+				"message Out {\n" +
+				"  map<string, int32> stock = 1;\n" +
+				"  int64 big = 2;\n" +
+				"  int32 delta = 3;\n" +
+				"}",
+		},
+		"root array": {
+			input: `[{"a": 1}]`,
+			expected: "syntax = \"proto3\";\n\n" +
+				"message Out {\n" +
+				"  message ItemsItem {\n" +
+				"    int64 a = 1;\n" +
+				"  }\n\n" +
+				"  repeated ItemsItem items = 1;\n" +
+				"}",
+		},
+		"invalid package name": {
+			input: `{"id": 1}`,
+			opts:  Options{PackageName: "api..v1"},
+			err:   ErrInvalidPackageName,
+		},
+		"invalid json": {
+			input: `{"invalid":json}`,
+			err:   ErrInvalidJSON,
+		},
+	}
+
+	for tname, tt := range tests {
+		t.Run(tname, func(t *testing.T) {
+			result, err := TransformToProto("Out", tt.input, tt.opts)
+			assertEqualErr(t, tt.err, err)
+			if tt.err == nil && result != tt.expected {
+				t.Errorf("wrong output\nexpected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSnakeName(t *testing.T) {
+	tests := map[string]string{
+		"id":         "id",
+		"userID":     "user_id",
+		"UserName":   "user_name",
+		"created-at": "created_at",
+		"HTTPStatus": "http_status",
+		"2fa":        "f_2_fa",
+		"!!":         "field",
+	}
+	for key, expected := range tests {
+		t.Run(key, func(t *testing.T) {
+			if got := snakeName(key); got != expected {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+		})
+	}
+}
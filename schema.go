@@ -0,0 +1,198 @@
+package json2go
+
+import (
+	"strings"
+	"time"
+)
+
+// schemaDialect identifies the JSON Schema version of generated documents.
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// SchemaGenerator generates JSON Schema documents from AST [Value], inferring
+// types the same way as [Transpiler]. Options specific to Go, like tags,
+// naming or the types of numbers and nulls, are ignored, and StringFormats
+// only opts in to the detection of "byte".
+// A SchemaGenerator is not safe for concurrent use.
+type SchemaGenerator struct {
+	Options
+}
+
+// NewSchemaGenerator returns a [SchemaGenerator] configured by opts.
+func NewSchemaGenerator(opts Options) *SchemaGenerator {
+	return &SchemaGenerator{Options: opts}
+}
+
+// Generate converts a [Value] AST to a JSON Schema document with the given title.
+//
+// Objects list the fields present in every sample as required and allow
+// no additional properties, unless they are typed as maps. Strings that all
+// have the same format are annotated with it, see [SchemaGenerator.stringFormat].
+func (g *SchemaGenerator) Generate(title string, v Value) (string, error) {
+	root := infer(v, inferConfig{format: g.stringFormat, discriminators: g.discriminatorKeys()})
+	detectMaps(root, "$", &g.Options)
+
+	schema := g.schema(root)
+	schema.Object = append([]Field{
+		{"$schema", Value{Kind: StringValue, Str: schemaDialect}},
+		{"title", Value{Kind: StringValue, Str: escapeJSON(title)}},
+	}, schema.Object...)
+
+	var buf strings.Builder
+	writeJSON(&buf, schema, 0)
+	return buf.String(), nil
+}
+
+// schema returns the schema of values of shape s.
+func (g *SchemaGenerator) schema(s *shape) Value {
+	if g.Unions && s.mapValue == nil && s.isUnion() {
+		return g.unionSchema(s)
+	}
+
+	var fields []Field
+	switch types := schemaTypes(s); len(types) {
+	case 0: // nothing observed, any value is accepted
+	case 1:
+		fields = append(fields, Field{"type", types[0]})
+	default:
+		fields = append(fields, Field{"type", Value{Kind: ArrayValue, Array: types}})
+	}
+
+	if s.kinds.has(StringValue) {
+		switch s.format {
+		case "":
+		case "byte":
+			fields = append(fields, Field{"contentEncoding", Value{Kind: StringValue, Str: "base64"}})
+		default:
+			fields = append(fields, Field{"format", Value{Kind: StringValue, Str: s.format}})
+		}
+	}
+
+	if s.kinds.has(ObjectValue) {
+		if s.mapValue != nil {
+			fields = append(fields, Field{"additionalProperties", g.schema(s.mapValue)})
+		} else {
+			fields = append(fields, g.objectSchema(s)...)
+		}
+	}
+
+	if s.kinds.has(ArrayValue) && (s.elem.kinds != 0 || s.elem.null) {
+		fields = append(fields, Field{"items", g.schema(s.elem)})
+	}
+
+	return Value{Kind: ObjectValue, Object: fields}
+}
+
+// objectSchema returns the keywords describing the fields of objects of shape s.
+// Objects that were only seen empty accept any fields.
+func (g *SchemaGenerator) objectSchema(s *shape) []Field {
+	if len(s.fields) == 0 {
+		return nil
+	}
+
+	properties := make([]Field, len(s.fields))
+	var required []Value
+	for i, f := range s.fields {
+		properties[i] = Field{f.key, g.schema(f.shape)}
+		if f.count == s.objects {
+			required = append(required, Value{Kind: StringValue, Str: f.key})
+		}
+	}
+
+	fields := []Field{{"properties", Value{Kind: ObjectValue, Object: properties}}}
+	if len(required) > 0 {
+		fields = append(fields, Field{"required", Value{Kind: ArrayValue, Array: required}})
+	}
+	return append(fields, Field{"additionalProperties", Value{Kind: BoolValue}})
+}
+
+// unionSchema returns the schema of objects of shape s as one of its variants,
+// each with the discriminator fixed to its value, see [Options.Unions].
+func (g *SchemaGenerator) unionSchema(s *shape) Value {
+	variants := make([]Value, 0, len(s.variantOrder)+1)
+	for _, value := range s.variantOrder {
+		variant := g.schema(s.variants[value])
+		for _, f := range variant.Object {
+			if f.K != "properties" {
+				continue
+			}
+			for i, p := range f.V.Object {
+				if p.K == s.discriminator {
+					f.V.Object[i].V = Value{Kind: ObjectValue, Object: []Field{
+						{"const", Value{Kind: StringValue, Str: value}},
+					}}
+				}
+			}
+		}
+		variants = append(variants, variant)
+	}
+	if s.null {
+		variants = append(variants, Value{Kind: ObjectValue, Object: []Field{
+			{"type", Value{Kind: StringValue, Str: "null"}},
+		}})
+	}
+
+	return Value{Kind: ObjectValue, Object: []Field{{"oneOf", Value{Kind: ArrayValue, Array: variants}}}}
+}
+
+// schemaTypes returns the JSON Schema types of values of shape s.
+// Integers mixed with decimals are numbers.
+func schemaTypes(s *shape) []Value {
+	var types []Value
+	add := func(name string) {
+		types = append(types, Value{Kind: StringValue, Str: name})
+	}
+
+	if s.kinds.has(BoolValue) {
+		add("boolean")
+	}
+	if s.kinds.has(StringValue) {
+		add("string")
+	}
+	switch {
+	case s.kinds.has(DecimalValue):
+		add("number")
+	case s.kinds.has(NumberValue):
+		add("integer")
+	}
+	if s.kinds.has(ObjectValue) {
+		add("object")
+	}
+	if s.kinds.has(ArrayValue) {
+		add("array")
+	}
+	if s.null {
+		add("null")
+	}
+	return types
+}
+
+// stringFormat classifies strings for [infer] with JSON Schema formats: the
+// ones reported by FormatDetector, "date-time" for RFC 3339 timestamps, and the
+// built-in formats, except "duration" since Go durations are not ISO 8601 ones.
+// Base64 ("byte") is only detected if StringFormats maps it, since plenty of
+// ordinary strings, like "johnsmith1234567", are valid base64 too.
+func (g *SchemaGenerator) stringFormat(s string) string {
+	if g.FormatDetector != nil {
+		if format := g.FormatDetector(s); format != "" {
+			return format
+		}
+	}
+
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return "date-time"
+	}
+	for _, f := range builtinFormats {
+		switch f.name {
+		case "duration":
+			continue
+		case "byte":
+			if _, ok := g.StringFormats["byte"]; !ok {
+				continue
+			}
+		}
+		if f.match(s) {
+			return f.name
+		}
+	}
+	return ""
+}
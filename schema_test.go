@@ -0,0 +1,217 @@
+package json2go
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchemaGenerator(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		opts     Options
+		expected string
+	}{
+		"scalars": {
+			input: `{"id": 1, "price": 9.99, "ok": true, "name": "a"}`,
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Out",
+  "type": "object",
+  "properties": {
+    "id": {
+      "type": "integer"
+    },
+    "price": {
+      "type": "number"
+    },
+    "ok": {
+      "type": "boolean"
+    },
+    "name": {
+      "type": "string"
+    }
+  },
+  "required": [
+    "id",
+    "price",
+    "ok",
+    "name"
+  ],
+  "additionalProperties": false
+}`,
+		},
+		"optional and nullable fields": {
+			input: `[{"a": 1, "b": "x"}, {"a": null}]`,
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Out",
+  "type": "array",
+  "items": {
+    "type": "object",
+    "properties": {
+      "a": {
+        "type": [
+          "integer",
+          "null"
+        ]
+      },
+      "b": {
+        "type": "string"
+      }
+    },
+    "required": [
+      "a"
+    ],
+    "additionalProperties": false
+  }
+}`,
+		},
+		"formats": {
+			input: `[{"at": "2024-05-01T12:00:00Z", "id": "9b2f7c52-3a0e-4a7b-8f43-2c1d5e6f7a8b", "data": "aGVsbG8gd29ybGQgaGVsbG8=", "ttl": "1h"}]`,
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Out",
+  "type": "array",
+  "items": {
+    "type": "object",
+    "properties": {
+      "at": {
+        "type": "string",
+        "format": "date-time"
+      },
+      "id": {
+        "type": "string",
+        "format": "uuid"
+      },
+      "data": {
+        "type": "string"
+      },
+      "ttl": {
+        "type": "string"
+      }
+    },
+    "required": [
+      "at",
+      "id",
+      "data",
+      "ttl"
+    ],
+    "additionalProperties": false
+  }
+}`,
+		},
+		"base64 only if mapped": {
+			input: `{"data": "aGVsbG8gd29ybGQgaGVsbG8="}`,
+			opts:  Options{StringFormats: map[string]string{"byte": "[]byte"}},
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Out",
+  "type": "object",
+  "properties": {
+    "data": {
+      "type": "string",
+      "contentEncoding": "base64"
+    }
+  },
+  "required": [
+    "data"
+  ],
+  "additionalProperties": false
+}`,
+		},
+		"plain strings are not base64": {
+			input: `{"username": "johnsmith1234567"}`,
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Out",
+  "type": "object",
+  "properties": {
+    "username": {
+      "type": "string"
+    }
+  },
+  "required": [
+    "username"
+  ],
+  "additionalProperties": false
+}`,
+		},
+		"maps, empty and mixed values": {
+			input: `{"users": {"1234": {"n": 1}}, "meta": {}, "values": [1, "a"], "none": []}`,
+			opts:  Options{DetectMaps: true},
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Out",
+  "type": "object",
+  "properties": {
+    "users": {
+      "type": "object",
+      "additionalProperties": {
+        "type": "object",
+        "properties": {
+          "n": {
+            "type": "integer"
+          }
+        },
+        "required": [
+          "n"
+        ],
+        "additionalProperties": false
+      }
+    },
+    "meta": {
+      "type": "object"
+    },
+    "values": {
+      "type": "array",
+      "items": {
+        "type": [
+          "string",
+          "integer"
+        ]
+      }
+    },
+    "none": {
+      "type": "array"
+    }
+  },
+  "required": [
+    "users",
+    "meta",
+    "values",
+    "none"
+  ],
+  "additionalProperties": false
+}`,
+		},
+	}
+
+	for tname, tt := range tests {
+		t.Run(tname, func(t *testing.T) {
+			result, err := TransformToSchema("Out", tt.input, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("wrong output\nexpected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+
+	t.Run("unions", func(t *testing.T) {
+		result, err := TransformToSchema("Out", `[{"type": "a", "x": 1}, {"type": "b"}, null]`, Options{Unions: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, want := range []string{`"oneOf": [`, `"const": "a"`, `"const": "b"`, `"type": "null"`} {
+			if !strings.Contains(result, want) {
+				t.Errorf("missing %s in:\n%s", want, result)
+			}
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := TransformToSchema("Out", `{"invalid":json}`, Options{})
+		assertEqualErr(t, ErrInvalidJSON, err)
+	})
+}
@@ -1,59 +1,396 @@
 package json2go
 
 import (
+	"fmt"
+	"go/format"
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
-	"unicode"
+	"time"
 )
 
+var sqlNullTypes = map[string]string{
+	"string":    "sql.NullString",
+	"int":       "sql.NullInt64",
+	"int64":     "sql.NullInt64",
+	"float64":   "sql.NullFloat64",
+	"bool":      "sql.NullBool",
+	"time.Time": "sql.NullTime",
+}
+
 // Transpiler transpiles AST [Value] to Go type definitions.
-type Transpiler struct{}
+// A Transpiler is not safe for concurrent use.
+type Transpiler struct {
+	Options
+
+	tags        []string          // struct tag keys written on every field
+	initialisms map[string]bool   // upper cased words kept in all caps
+	formats     map[string]goType // string format -> type used for it
+	imports     map[string]bool   // import paths used by the written types
+	decls       declarations      // nested types declared by name
+	current     *shape            // shape of the type being declared
+}
 
 func NewTranspiler() *Transpiler { return &Transpiler{} }
 
+// NewTranspilerWithOptions returns a [Transpiler] configured by opts.
+func NewTranspilerWithOptions(opts Options) *Transpiler {
+	return &Transpiler{Options: opts}
+}
+
 // Transpile converts a [Value] AST to Go type definitions.
+// The includeTags argument takes precedence over [Options.OmitTags].
 func (t *Transpiler) Transpile(structName string, v Value, includeTags bool) (string, error) {
+	if !includeTags {
+		return t.transpile(structName, valueSeq([]Value{v}), nil)
+	}
+	return t.transpile(structName, valueSeq([]Value{v}), t.tagKeys())
+}
+
+// Generate converts a [Value] AST to Go type definitions, as configured by [Options].
+//
+// Every element of an array is merged into a single element type,
+// so objects contribute the union of their fields.
+func (t *Transpiler) Generate(structName string, v Value) (string, error) {
+	return t.GenerateSamples(structName, []Value{v})
+}
+
+// GenerateSamples converts several [Value] ASTs, samples of the same document,
+// to a single set of Go type definitions, as configured by [Options].
+//
+// The samples are merged like the elements of an array: fields missing in
+// some samples are optional, tagged as [Options.OmitStyle] selects, and values
+// null in some samples are nullable. Since the zero OmitStyle adds no tag
+// option, optional fields are only marked with OmitEmpty or OmitZero.
+// With no samples the type is any.
+func (t *Transpiler) GenerateSamples(structName string, samples []Value) (string, error) {
+	return t.generate(structName, valueSeq(samples))
+}
+
+// generate is [Transpiler.GenerateSamples] for samples that are parsed as
+// they are merged, failing with the first error yielded by samples.
+func (t *Transpiler) generate(structName string, samples iter.Seq2[Value, error]) (string, error) {
+	if t.OmitTags {
+		return t.transpile(structName, samples, nil)
+	}
+	return t.transpile(structName, samples, t.tagKeys())
+}
+
+// GenerateFromSchema converts a JSON Schema document to Go type definitions,
+// as configured by [Options], written the same way as the ones inferred from samples.
+//
+// Properties not listed as required are optional. String enums are declared
+// as string types with a constant per value, and strings of the "date-time"
+// format are time.Time unless [Options.StringFormats] maps it to another type.
+// Objects referenced with $ref are declared as named types, and oneOf
+// alternatives that fix a property to different strings are unions
+// if [Options.Unions] is set.
+func (t *Transpiler) GenerateFromSchema(structName string, schema Value) (string, error) {
+	tags := t.tagKeys()
+	if t.OmitTags {
+		tags = nil
+	}
+	if err := t.reset(structName, tags); err != nil {
+		return "", err
+	}
+	if _, ok := t.formats["date-time"]; !ok {
+		t.formats["date-time"] = goType{name: "time.Time", importPath: "time"}
+	}
+
+	root, err := readSchema(schema, structName, t.initialisms)
+	if err != nil {
+		return "", err
+	}
+	return t.write(structName, root)
+}
+
+func (t *Transpiler) transpile(structName string, samples iter.Seq2[Value, error], tags []string) (string, error) {
+	if err := t.reset(structName, tags); err != nil {
+		return "", err
+	}
+
+	root, err := inferSamples(samples, inferConfig{format: t.stringFormat, discriminators: t.discriminatorKeys()})
+	if err != nil {
+		return "", err
+	}
+	detectMaps(root, "$", &t.Options)
+	return t.write(structName, root)
+}
+
+// reset validates the names and prepares the state for writing a new set of types.
+func (t *Transpiler) reset(structName string, tags []string) error {
+	if !isValidTypeName(structName) {
+		return ErrInvalidStructName
+	}
+	if t.PackageName != "" && !isValidIdentifier(t.PackageName) {
+		return ErrInvalidPackageName
+	}
+
+	t.tags = tags
+	t.initialisms = initialismSet(t.Initialisms)
+	t.imports = make(map[string]bool)
+	t.formats = make(map[string]goType, len(t.StringFormats)+1)
+	if t.DetectTime {
+		t.formats["date-time"] = goType{name: "time.Time", importPath: "time"}
+	}
+	for format, spec := range t.StringFormats {
+		t.formats[format] = parseGoType(spec)
+	}
+	ranges := t.NumberType == "" && (t.SizedInts || t.UnsignedInts || t.BigIntStyle == BigIntUint64)
+	t.decls = newDeclarations(structName, ranges)
+	return nil
+}
+
+// write writes the root type and every type declared while writing it.
+func (t *Transpiler) write(structName string, root *shape) (string, error) {
+	t.decls.named[root] = structName
+
+	var buf strings.Builder
+	t.writeDecl(&buf, namedShape{name: structName, shape: root})
+
+	for i := 0; i < len(t.decls.queue); i++ {
+		buf.WriteString("\n\n")
+		t.writeDecl(&buf, t.decls.queue[i])
+	}
+
+	if t.PackageName != "" {
+		return t.formatFile(buf.String())
+	}
+	return buf.String(), nil
+}
+
+// formatFile wraps type declarations into a complete, gofmt'ed Go source file.
+func (t *Transpiler) formatFile(decls string) (string, error) {
 	var buf strings.Builder
+	buf.WriteString("// Code generated by json2go. DO NOT EDIT.\n\npackage ")
+	buf.WriteString(t.PackageName)
+	buf.WriteString("\n\n")
+
+	if len(t.imports) > 0 {
+		// standard library first, then a separate group for everything else
+		paths := slices.SortedFunc(maps.Keys(t.imports), func(a, b string) int {
+			if stdA, stdB := isStdImport(a), isStdImport(b); stdA != stdB {
+				if stdA {
+					return -1
+				}
+				return 1
+			}
+			return strings.Compare(a, b)
+		})
+
+		buf.WriteString("import (\n")
+		for i, path := range paths {
+			if i > 0 && isStdImport(paths[i-1]) && !isStdImport(path) {
+				buf.WriteByte('\n')
+			}
+			buf.WriteString("\t\"")
+			buf.WriteString(path)
+			buf.WriteString("\"\n")
+		}
+		buf.WriteString(")\n\n")
+	}
+
+	buf.WriteString(decls)
+	buf.WriteByte('\n')
+
+	src, err := format.Source([]byte(buf.String()))
+	if err != nil {
+		return "", err
+	}
+	return string(src), nil
+}
+
+// isStdImport reports whether path belongs to the standard library,
+// whose first path element never has a dot.
+func isStdImport(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// tagKeys returns the non-empty keys of [Options.Tags], trimmed of spaces,
+// or "json" if there are none.
+func (t *Transpiler) tagKeys() []string {
+	var keys []string
+	for _, key := range t.Tags {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return []string{"json"}
+	}
+	return keys
+}
+
+func (t *Transpiler) writeDecl(buf *strings.Builder, d namedShape) {
+	name, s := d.name, d.shape
+	t.current = s
+	if t.isUnion(s) {
+		t.writeUnion(buf, name, s)
+		return
+	}
+	if kind, _ := s.kind(); kind == StringValue && len(s.enum) > 0 {
+		t.writeEnum(buf, name, s)
+		return
+	}
+
 	buf.WriteString("type ")
-	buf.WriteString(structName)
+	buf.WriteString(name)
+	buf.WriteByte(' ')
+	if kind, _ := s.kinds.single(); kind == ObjectValue && s.mapValue == nil {
+		t.writeInlineStruct(buf, name, s, 0)
+	} else {
+		t.writeInlineType(buf, name, s, 0)
+		t.writeKindComment(buf, s)
+	}
 
-	switch v.Kind {
-	case ArrayValue:
-		buf.WriteString(" [")
-		if len(v.Array) == 0 {
-			buf.WriteString("]any")
-		} else {
-			buf.WriteByte(']')
-			t.writeInlineType(&buf, structName+"Item", v.Array[0], includeTags, 0)
+	if d.union != "" {
+		buf.WriteString("\n\nfunc (")
+		buf.WriteString(name)
+		buf.WriteString(") is")
+		buf.WriteString(d.union)
+		buf.WriteString("() {}")
+	}
+}
+
+// writeEnum writes a string type with a constant for each value allowed by s.
+func (t *Transpiler) writeEnum(buf *strings.Builder, name string, s *shape) {
+	fmt.Fprintf(buf, "type %s string\n\nconst (\n", name)
+	for _, value := range s.enum {
+		constName := t.decls.unique(name + goName(unescapeJSON(value), t.initialisms))
+		fmt.Fprintf(buf, "\t%s %s = %s\n", constName, name, goStringLiteral(value))
+	}
+	buf.WriteByte(')')
+}
+
+// isUnion reports whether s is written as a union of its variants,
+// see [Options.Unions].
+func (t *Transpiler) isUnion(s *shape) bool {
+	return t.Unions && s.mapValue == nil && s.isUnion()
+}
+
+// writeUnion writes a struct holding one of the variants of s, which are
+// queued to be declared, and the methods converting it from and to json.
+// Variants that are named shapes are declared under their own name, shared
+// with every other use of the shape.
+func (t *Transpiler) writeUnion(buf *strings.Builder, name string, s *shape) {
+	t.imports["encoding/json"] = true
+	t.imports["fmt"] = true
+
+	iface := t.decls.unique(name + "Variant")
+	variants := make([]string, len(s.variantOrder))
+	var shared []string // variants declared by declareNamed, which may already be written
+	for i, value := range s.variantOrder {
+		variant := s.variants[value]
+		if variant.name != "" {
+			variants[i] = t.decls.declareNamed(variant)
+			shared = append(shared, variants[i])
+			continue
 		}
+		variants[i] = t.decls.unique(name + goName(value, t.initialisms))
+		t.decls.queue = append(t.decls.queue, namedShape{variants[i], variant, name})
+	}
+	probe := goName(s.discriminator, t.initialisms)
 
-	case ObjectValue:
-		buf.WriteByte(' ')
-		t.writeInlineStruct(&buf, structName, v.Object, includeTags, 0)
+	fmt.Fprintf(buf, "type %s struct {\n\tValue %s\n}\n\n", name, iface)
+	fmt.Fprintf(buf, "// %s is one of %s.\n", iface, strings.Join(variants, ", "))
+	fmt.Fprintf(buf, "type %s interface {\n\tis%s()\n}\n\n", iface, name)
 
-	default:
-		buf.WriteByte(' ')
-		t.writeScalarType(&buf, v)
+	fmt.Fprintf(buf, "func (u *%s) UnmarshalJSON(data []byte) error {\n", name)
+	fmt.Fprintf(buf, "\tvar probe struct {\n\t\t%s string `json:%q`\n\t}\n", probe, s.discriminator)
+	buf.WriteString("\tif err := json.Unmarshal(data, &probe); err != nil {\n\t\treturn err\n\t}\n")
+	fmt.Fprintf(buf, "\tswitch probe.%s {\n", probe)
+	for i, value := range s.variantOrder {
+		fmt.Fprintf(buf, "\tcase %s:\n\t\tu.Value = new(%s)\n", goStringLiteral(value), variants[i])
 	}
+	fmt.Fprintf(buf, "\tdefault:\n\t\treturn fmt.Errorf(\"unknown %s %s %%q\", probe.%s)\n\t}\n", name, s.discriminator, probe)
+	buf.WriteString("\treturn json.Unmarshal(data, u.Value)\n}\n\n")
 
-	return buf.String(), nil
+	fmt.Fprintf(buf, "func (u %s) MarshalJSON() ([]byte, error) {\n\treturn json.Marshal(u.Value)\n}", name)
+	for _, variant := range shared {
+		fmt.Fprintf(buf, "\n\nfunc (%s) is%s() {}", variant, name)
+	}
 }
 
-func (t *Transpiler) writeInlineType(buf *strings.Builder, name string, v Value, includeTags bool, depth int) {
-	switch v.Kind {
-	case ObjectValue:
-		t.writeInlineStruct(buf, name, v.Object, includeTags, depth)
+func (t *Transpiler) writeInlineType(buf *strings.Builder, name string, s *shape, depth int) {
+	kind, ok := s.kind()
+	if !ok {
+		buf.WriteString("any")
+		return
+	}
 
-	case ArrayValue:
-		buf.WriteByte('[')
-		if len(v.Array) == 0 {
-			buf.WriteString("]any")
-		} else {
-			buf.WriteByte(']')
-			t.writeInlineType(buf, name+"Item", v.Array[0], includeTags, depth)
+	var scalar goType // its import is recorded only if it is written
+	if kind != ObjectValue && kind != ArrayValue && s.target == nil && len(s.enum) == 0 {
+		scalar = t.scalarType(s, kind)
+	}
+
+	body := s
+	if s.target != nil {
+		body = s.target
+	}
+
+	closeOptional := false
+	nilable := kind == ArrayValue || body.mapValue != nil || strings.HasPrefix(scalar.name, "*") || strings.HasPrefix(scalar.name, "[]")
+	if s.null && !nilable {
+		switch t.NullStyle {
+		case NullSQL:
+			if typ, ok := sqlNullTypes[scalar.name]; ok {
+				t.imports["database/sql"] = true
+				buf.WriteString(typ)
+				return
+			}
+			buf.WriteByte('*')
+		case NullOptional:
+			buf.WriteString("Optional[")
+			closeOptional = true
+		default:
+			buf.WriteByte('*')
 		}
+	}
+
+	switch {
+	case s.target != nil:
+		buf.WriteString(t.decls.declareNamed(s.target))
+
+	case kind == ObjectValue && s.mapValue != nil:
+		buf.WriteString("map[string]")
+		t.writeInlineType(buf, name+"Value", s.mapValue, depth)
+
+	case kind == StringValue && len(s.enum) > 0:
+		buf.WriteString(t.decls.declare(name, s))
+
+	case t.isUnion(s):
+		buf.WriteString(t.decls.declare(name, s))
+
+	case kind == ObjectValue && t.NamedTypes:
+		buf.WriteString(t.decls.declare(name, s))
+
+	case kind == ObjectValue:
+		t.writeInlineStruct(buf, name, s, depth)
+
+	case kind == ArrayValue:
+		buf.WriteString("[]")
+		t.writeInlineType(buf, name+"Item", s.elem, depth)
 
 	default:
-		t.writeScalarType(buf, v)
+		buf.WriteString(t.use(scalar))
+	}
+
+	if closeOptional {
+		buf.WriteByte(']')
+	}
+}
+
+// writeKindComment explains a type of s written as any, if it has one:
+// only nulls or values of conflicting kinds were seen.
+func (t *Transpiler) writeKindComment(buf *strings.Builder, s *shape) {
+	if s.onlyNull() {
+		buf.WriteString(" // only null values seen, type unknown")
+	} else if mixed := s.mixedKinds(); mixed != "" {
+		buf.WriteString(" // mixed types: ")
+		buf.WriteString(mixed)
 	}
 }
 
@@ -63,88 +400,216 @@ func (t *Transpiler) writeIndent(buf *strings.Builder, depth int) {
 	}
 }
 
-func (t *Transpiler) writeInlineStruct(buf *strings.Builder, name string, fields []Field, includeTags bool, depth int) {
+func (t *Transpiler) writeInlineStruct(buf *strings.Builder, name string, s *shape, depth int) {
+	fieldNames := make([]string, len(s.fields))
+	for i, f := range s.fields {
+		fieldNames[i] = goName(f.key, t.initialisms)
+	}
+	uniqueNames(fieldNames)
+
 	buf.WriteString("struct {\n")
-	for _, f := range fields {
-		fieldName := t.sanitizeFieldName(f.K)
+	for i, f := range s.fields {
+		fieldName := fieldNames[i]
 		t.writeIndent(buf, depth+1)
 		buf.WriteString(fieldName)
 		buf.WriteByte(' ')
-		t.writeInlineType(buf, name+fieldName, f.V, includeTags, depth+1)
-		if includeTags {
-			buf.WriteString(" `json:\"")
-			buf.WriteString(f.K)
-			buf.WriteString("\"`")
+		quoted, isQuoted := t.quotedType(f.shape)
+		if isQuoted {
+			buf.WriteString(quoted)
+		} else {
+			if f.shape.target != nil && !f.shape.null && embeds(f.shape.target, t.current, make(map[*shape]bool)) {
+				buf.WriteByte('*') // a recursive type needs a pointer to be finite
+			}
+			t.writeInlineType(buf, name+fieldName, f.shape, depth+1)
 		}
+		t.writeTags(buf, f.key, f.count < s.objects, isQuoted)
+		t.writeKindComment(buf, f.shape)
 		buf.WriteByte('\n')
 	}
 	t.writeIndent(buf, depth)
 	buf.WriteByte('}')
 }
 
-func (t *Transpiler) writeScalarType(buf *strings.Builder, v Value) {
-	switch v.Kind {
+// embeds reports whether values of shape s hold a value of shape target
+// directly, not through a pointer, slice or map, so that a struct for
+// target with a field of shape s would have an infinite size.
+func embeds(s, target *shape, seen map[*shape]bool) bool {
+	if s == target {
+		return true
+	}
+	if seen[s] || s.mapValue != nil {
+		return false
+	}
+	seen[s] = true
+
+	for _, f := range s.fields {
+		if f.shape.null || f.shape.kinds != 1<<ObjectValue {
+			continue
+		}
+		next := f.shape
+		if next.target != nil {
+			next = next.target
+		}
+		if embeds(next, target, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// goStringLiteral returns a Go string literal of a json string as written in json.
+// The string is decoded first, since json escapes like surrogate pairs are not valid in Go.
+func goStringLiteral(raw string) string {
+	return strconv.Quote(unescapeJSON(raw))
+}
+
+// quotedType returns the type of a field whose every sample is a string holding
+// a number or a boolean, see [Options.DetectQuoted].
+func (t *Transpiler) quotedType(s *shape) (string, bool) {
+	if !t.DetectQuoted || s.kinds != 1<<StringValue {
+		return "", false
+	}
+
+	typ, ok := quotedTypes[s.format]
+	if ok && s.null {
+		typ = "*" + typ // encoding/json supports ,string through one pointer
+	}
+	return typ, ok
+}
+
+func (t *Transpiler) writeTags(buf *strings.Builder, key string, optional, quoted bool) {
+	if len(t.tags) == 0 {
+		return
+	}
+
+	buf.WriteString(" `")
+	for i, tag := range t.tags {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(tag)
+		buf.WriteString(":\"")
+		buf.WriteString(key)
+		if optional && tag == "json" {
+			switch t.OmitStyle {
+			case OmitEmpty:
+				buf.WriteString(",omitempty")
+			case OmitZero:
+				buf.WriteString(",omitzero")
+			}
+		}
+		if quoted && tag == "json" {
+			buf.WriteString(",string")
+		}
+		buf.WriteByte('"')
+	}
+	buf.WriteByte('`')
+}
+
+// scalarType returns the type of scalar values of shape s, without recording
+// its import, since it may be replaced by a nullable type, see [Transpiler.use].
+func (t *Transpiler) scalarType(s *shape, kind ValueType) goType {
+	if t.NumberType != "" && (kind == NumberValue || kind == DecimalValue) {
+		return parseGoType(t.NumberType)
+	}
+
+	switch kind {
 	case StringValue:
-		buf.WriteString("string")
+		if typ, ok := t.formats[s.format]; ok {
+			return typ
+		}
+		return goType{name: "string"}
 	case NumberValue:
-		buf.WriteString("int")
+		switch {
+		case s.bigInts > 0:
+			return t.bigIntType(s)
+		case t.UnsignedInts && s.minInt >= 0:
+			return goType{name: "uint64"}
+		case t.SizedInts && !s.fitsInt32():
+			return goType{name: "int64"}
+		default:
+			return goType{name: "int"}
+		}
 	case DecimalValue:
-		buf.WriteString("float64")
+		return goType{name: "float64"}
 	case BoolValue:
-		buf.WriteString("bool")
+		return goType{name: "bool"}
 	default:
-		buf.WriteString("any")
+		return goType{name: "any"}
 	}
 }
 
-func (t *Transpiler) sanitizeFieldName(jsonKey string) string {
-	if jsonKey == "" {
-		return "Field"
+// use records the import of typ and returns its name.
+func (t *Transpiler) use(typ goType) string {
+	if typ.importPath != "" {
+		t.imports[typ.importPath] = true
 	}
+	return typ.name
+}
 
-	var result strings.Builder
-	result.Grow(len(jsonKey))
+// bigIntType is the type of integers where at least one sample overflows int64.
+func (t *Transpiler) bigIntType(s *shape) goType {
+	switch {
+	case t.BigIntStyle == BigIntUint64 && !s.overUint64 && s.minInt >= 0:
+		return goType{name: "uint64"}
+	case t.BigIntStyle == BigIntBig:
+		return goType{name: "*big.Int", importPath: "math/big"}
+	default:
+		return goType{name: "json.Number", importPath: "encoding/json"}
+	}
+}
 
-	capitalize := true
-	for _, r := range jsonKey {
-		if r == '_' {
-			capitalize = true
-			continue
-		}
-		if capitalize {
-			result.WriteRune(unicode.ToUpper(r))
-			capitalize = false
-		} else {
-			result.WriteRune(r)
-		}
+// stringFormat classifies string values for [infer] as configured by [Options].
+// Only formats that are mapped to a type are detected.
+func (t *Transpiler) stringFormat(s string) string {
+	if len(t.formats) == 0 && !t.DetectQuoted {
+		return ""
 	}
 
-	name := result.String()
-	if name != "" && isValidIdentifier(name) {
-		return name
+	if t.DetectQuoted {
+		if format := quotedFormat(s); format != "" {
+			return format
+		}
 	}
 
-	return t.sanitizeInvalidIdentifier(jsonKey)
-}
+	if t.FormatDetector != nil {
+		if format := t.FormatDetector(s); format != "" {
+			if _, ok := t.formats[format]; ok {
+				return format
+			}
+		}
+	}
 
-func (t *Transpiler) sanitizeInvalidIdentifier(jsonKey string) string {
-	var result strings.Builder
-	for i, r := range jsonKey {
-		if unicode.IsLetter(r) || r == '_' || (i > 0 && unicode.IsDigit(r)) {
-			result.WriteRune(r)
-		} else if i > 0 && r == '-' {
-			result.WriteRune('_')
+	if _, ok := t.formats["date-time"]; ok && isRFC3339(s) {
+		return "date-time"
+	}
+	if _, ok := t.formats["date"]; ok && !isRFC3339(s) && t.isDate(s) {
+		return "date"
+	}
+	for _, f := range builtinFormats {
+		if _, ok := t.formats[f.name]; ok && f.match(s) {
+			return f.name
 		}
 	}
+	return ""
+}
 
-	name := result.String()
-	if name == "" || (!unicode.IsLetter(rune(name[0])) && name[0] != '_') {
-		var b strings.Builder
-		b.Grow(len(name) + 1)
-		b.WriteByte('F')
-		b.WriteString(name)
-		return b.String()
+// isDate reports whether s parses with one of [Options.TimeLayouts].
+func (t *Transpiler) isDate(s string) bool {
+	layouts := t.TimeLayouts
+	if len(layouts) == 0 {
+		layouts = []string{time.DateOnly}
+	}
+	for _, layout := range layouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
 	}
+	return false
+}
 
-	return name
+// isRFC3339 reports whether s is a timestamp time.Time unmarshals from.
+func isRFC3339(s string) bool {
+	_, err := time.Parse(time.RFC3339, s)
+	return err == nil
 }
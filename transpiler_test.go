@@ -90,8 +90,8 @@ func TestTranspiler_Transpile_WithTags(t *testing.T) {
 				if !strings.Contains(result, "type Users []struct {") {
 					t.Errorf("missing Users array type with inline struct, got: %s", result)
 				}
-				if !strings.Contains(result, "Id int `json:\"id\"`") {
-					t.Errorf("missing Id field")
+				if !strings.Contains(result, "ID int `json:\"id\"`") {
+					t.Errorf("missing ID field")
 				}
 			},
 		},
@@ -263,3 +263,182 @@ func TestTranspiler_ParserIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestTranspiler_Transpile_NamedTypes(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		name     string
+		expected string
+	}{
+		"nested object": {
+			name:  "User",
+			input: `{"name": "Alice", "address": {"city": "Kyiv"}}`,
+			expected: "type User struct {\n" +
+				"\tName string `json:\"name\"`\n" +
+				"\tAddress UserAddress `json:\"address\"`\n" +
+				"}\n\n" +
+				"type UserAddress struct {\n" +
+				"\tCity string `json:\"city\"`\n" +
+				"}",
+		},
+		"array of objects": {
+			name:  "Users",
+			input: `[{"id": 1, "tags": [{"k": "v"}]}]`,
+			expected: "type Users []UsersItem\n\n" +
+				"type UsersItem struct {\n" +
+				"\tID int `json:\"id\"`\n" +
+				"\tTags []UsersItemTagsItem `json:\"tags\"`\n" +
+				"}\n\n" +
+				"type UsersItemTagsItem struct {\n" +
+				"\tK string `json:\"k\"`\n" +
+				"}",
+		},
+		"identical objects share a type": {
+			name:  "Post",
+			input: `{"created_by": {"id": 1, "name": "a"}, "updated_by": {"name": "b", "id": 2}}`,
+			expected: "type Post struct {\n" +
+				"\tCreatedBy PostCreatedBy `json:\"created_by\"`\n" +
+				"\tUpdatedBy PostCreatedBy `json:\"updated_by\"`\n" +
+				"}\n\n" +
+				"type PostCreatedBy struct {\n" +
+				"\tID int `json:\"id\"`\n" +
+				"\tName string `json:\"name\"`\n" +
+				"}",
+		},
+		"objects with different integers share a type": {
+			name:  "Root",
+			input: `{"created_by": {"id": 1, "name": "a"}, "updated_by": {"id": 2000000000000, "name": "b"}, "a": {"x": 1}, "b": {"x": -1}}`,
+			expected: "type Root struct {\n" +
+				"\tCreatedBy RootCreatedBy `json:\"created_by\"`\n" +
+				"\tUpdatedBy RootCreatedBy `json:\"updated_by\"`\n" +
+				"\tA RootA `json:\"a\"`\n" +
+				"\tB RootA `json:\"b\"`\n" +
+				"}\n\n" +
+				"type RootCreatedBy struct {\n" +
+				"\tID int `json:\"id\"`\n" +
+				"\tName string `json:\"name\"`\n" +
+				"}\n\n" +
+				"type RootA struct {\n" +
+				"\tX int `json:\"x\"`\n" +
+				"}",
+		},
+		"nullable object shares a type": {
+			name:  "Root",
+			input: `{"a": {"x": 1}, "l": [{"b": {"x": 1}}, {"b": null}]}`,
+			expected: "type Root struct {\n" +
+				"\tA RootA `json:\"a\"`\n" +
+				"\tL []RootLItem `json:\"l\"`\n" +
+				"}\n\n" +
+				"type RootA struct {\n" +
+				"\tX int `json:\"x\"`\n" +
+				"}\n\n" +
+				"type RootLItem struct {\n" +
+				"\tB *RootA `json:\"b\"`\n" +
+				"}",
+		},
+		"different objects are not shared": {
+			name:  "Post",
+			input: `{"author": {"id": 1}, "editor": {"id": 1, "name": "b"}}`,
+			expected: "type Post struct {\n" +
+				"\tAuthor PostAuthor `json:\"author\"`\n" +
+				"\tEditor PostEditor `json:\"editor\"`\n" +
+				"}\n\n" +
+				"type PostAuthor struct {\n" +
+				"\tID int `json:\"id\"`\n" +
+				"}\n\n" +
+				"type PostEditor struct {\n" +
+				"\tID int `json:\"id\"`\n" +
+				"\tName string `json:\"name\"`\n" +
+				"}",
+		},
+		"array element shares a type with object": {
+			name:  "Feed",
+			input: `{"items": [{"id": 1}, {"id": 2}], "pinned": {"id": 3}}`,
+			expected: "type Feed struct {\n" +
+				"\tItems []FeedItemsItem `json:\"items\"`\n" +
+				"\tPinned FeedItemsItem `json:\"pinned\"`\n" +
+				"}\n\n" +
+				"type FeedItemsItem struct {\n" +
+				"\tID int `json:\"id\"`\n" +
+				"}",
+		},
+		"scalar root": {
+			name:     "Count",
+			input:    `42`,
+			expected: "type Count int",
+		},
+	}
+	for tname, tt := range tests {
+		t.Run(tname, func(t *testing.T) {
+			v, err := NewParser(NewLexer([]byte(tt.input))).Parse()
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			transpiler := NewTranspiler()
+			transpiler.NamedTypes = true
+			result, err := transpiler.Transpile(tt.name, v, true)
+			if err != nil {
+				t.Fatalf("transpile error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("wrong output\nexpected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestTranspiler_Transpile_NullStyle(t *testing.T) {
+	input := `[{"age": 1, "user": {"id": 1}, "tags": ["a"], "note": null}, {"age": null, "user": null, "tags": null, "note": null}]`
+	tests := map[string]struct {
+		style    NullStyle
+		expected []string
+	}{
+		"pointer": {
+			style: NullPointer,
+			expected: []string{
+				"Age *int `json:\"age\"`",
+				"User *struct {",
+				"Tags []string `json:\"tags\"`",
+				"Note any `json:\"note\"` // only null values seen, type unknown",
+			},
+		},
+		"sql": {
+			style: NullSQL,
+			expected: []string{
+				"Age sql.NullInt64 `json:\"age\"`",
+				"User *struct {",
+				"Tags []string `json:\"tags\"`",
+			},
+		},
+		"optional": {
+			style: NullOptional,
+			expected: []string{
+				"Age Optional[int] `json:\"age\"`",
+				"User Optional[struct {",
+				"}] `json:\"user\"`",
+				"Tags []string `json:\"tags\"`",
+			},
+		},
+	}
+	for tname, tt := range tests {
+		t.Run(tname, func(t *testing.T) {
+			v, err := NewParser(NewLexer([]byte(input))).Parse()
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			transpiler := NewTranspiler()
+			transpiler.NullStyle = tt.style
+			result, err := transpiler.Transpile("Out", v, true)
+			if err != nil {
+				t.Fatalf("transpile error: %v", err)
+			}
+			for _, exp := range tt.expected {
+				if !strings.Contains(result, exp) {
+					t.Errorf("missing %q, got:\n%s", exp, result)
+				}
+			}
+		})
+	}
+}
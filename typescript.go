@@ -0,0 +1,177 @@
+package json2go
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TypeScriptGenerator generates TypeScript declarations from AST [Value],
+// inferring types the same way as [Transpiler]. Nested objects are declared
+// as named interfaces, and values of conflicting kinds are typed as unions.
+// Options specific to Go, like tags or the types of numbers and nulls, are ignored.
+// A TypeScriptGenerator is not safe for concurrent use.
+type TypeScriptGenerator struct {
+	Options
+
+	initialisms map[string]bool // upper cased words kept in all caps
+	decls       declarations    // nested interfaces declared by name
+}
+
+// NewTypeScriptGenerator returns a [TypeScriptGenerator] configured by opts.
+func NewTypeScriptGenerator(opts Options) *TypeScriptGenerator {
+	return &TypeScriptGenerator{Options: opts}
+}
+
+// Generate converts a [Value] AST to exported TypeScript declarations, an
+// interface if the value is an object, a type alias otherwise.
+//
+// Fields missing in some samples are optional, and fields null in some
+// samples are a union with null.
+func (g *TypeScriptGenerator) Generate(name string, v Value) (string, error) {
+	if !isValidTypeName(name) {
+		return "", ErrInvalidStructName
+	}
+
+	g.initialisms = initialismSet(g.Initialisms)
+	g.decls = newDeclarations(name, false) // every integer is a number
+
+	root := infer(v, inferConfig{discriminators: g.discriminatorKeys()})
+	detectMaps(root, "$", &g.Options)
+
+	var buf strings.Builder
+	if root.kinds == 1<<ObjectValue && !root.null && root.mapValue == nil && len(root.fields) > 0 && !g.isUnion(root) {
+		g.writeInterface(&buf, name, root, "", "")
+	} else {
+		buf.WriteString("export type ")
+		buf.WriteString(name)
+		buf.WriteString(" = ")
+		buf.WriteString(g.tsType(name, root))
+		buf.WriteByte(';')
+	}
+
+	for i := 0; i < len(g.decls.queue); i++ {
+		buf.WriteString("\n\n")
+		d := g.decls.queue[i]
+		if g.isUnion(d.shape) {
+			g.writeUnion(&buf, d.name, d.shape)
+		} else {
+			g.writeInterface(&buf, d.name, d.shape, "", "")
+		}
+	}
+	return buf.String(), nil
+}
+
+// isUnion reports whether s is written as a union of its variants,
+// see [Options.Unions].
+func (g *TypeScriptGenerator) isUnion(s *shape) bool {
+	return g.Unions && s.mapValue == nil && s.isUnion()
+}
+
+// writeUnion writes a discriminated union of the variants of s,
+// each declared as an interface right after it.
+func (g *TypeScriptGenerator) writeUnion(buf *strings.Builder, name string, s *shape) {
+	variants := make([]string, len(s.variantOrder))
+	for i, value := range s.variantOrder {
+		variants[i] = g.decls.unique(name + goName(value, g.initialisms))
+	}
+
+	buf.WriteString("export type ")
+	buf.WriteString(name)
+	buf.WriteString(" = ")
+	buf.WriteString(strings.Join(variants, " | "))
+	buf.WriteByte(';')
+
+	for i, value := range s.variantOrder {
+		buf.WriteString("\n\n")
+		g.writeInterface(buf, variants[i], s.variants[value], s.discriminator, value)
+	}
+}
+
+// writeInterface writes an interface with the fields of s. If discriminator
+// is set, s is a union variant and that field is typed as the tag literal.
+func (g *TypeScriptGenerator) writeInterface(buf *strings.Builder, name string, s *shape, discriminator, tag string) {
+	typeNames := make([]string, len(s.fields))
+	for i, f := range s.fields {
+		typeNames[i] = goName(f.key, g.initialisms)
+	}
+	uniqueNames(typeNames)
+
+	buf.WriteString("export interface ")
+	buf.WriteString(name)
+	buf.WriteString(" {\n")
+	for i, f := range s.fields {
+		buf.WriteString("  ")
+		if isTSIdentifier(f.key) {
+			buf.WriteString(f.key)
+		} else {
+			buf.WriteString(`"` + f.key + `"`) // keys keep their json escapes, valid in TypeScript too
+		}
+		if f.count < s.objects {
+			buf.WriteByte('?')
+		}
+		buf.WriteString(": ")
+		if discriminator != "" && f.key == discriminator {
+			buf.WriteString(`"` + tag + `"`)
+		} else {
+			buf.WriteString(g.tsType(name+typeNames[i], f.shape))
+		}
+		buf.WriteString(";\n")
+	}
+	buf.WriteByte('}')
+}
+
+// tsType returns the type of values of shape s, declaring nested
+// interfaces named after name.
+func (g *TypeScriptGenerator) tsType(name string, s *shape) string {
+	var types []string
+	if s.kinds.has(BoolValue) {
+		types = append(types, "boolean")
+	}
+	if s.kinds.has(StringValue) {
+		types = append(types, "string")
+	}
+	if s.kinds.has(NumberValue) || s.kinds.has(DecimalValue) {
+		types = append(types, "number")
+	}
+
+	if s.kinds.has(ObjectValue) {
+		switch {
+		case s.mapValue != nil:
+			types = append(types, "Record<string, "+g.tsType(name+"Value", s.mapValue)+">")
+		case len(s.fields) == 0:
+			types = append(types, "Record<string, unknown>")
+		default:
+			types = append(types, g.decls.declare(name, s))
+		}
+	}
+
+	if s.kinds.has(ArrayValue) {
+		elem := g.tsType(name+"Item", s.elem)
+		if strings.Contains(elem, " | ") {
+			elem = "(" + elem + ")"
+		}
+		types = append(types, elem+"[]")
+	}
+
+	if s.null {
+		types = append(types, "null")
+	}
+	if len(types) == 0 {
+		return "unknown"
+	}
+	return strings.Join(types, " | ")
+}
+
+// isTSIdentifier reports whether key can be written as a property name without quotes.
+func isTSIdentifier(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, r := range key {
+		if r == '_' || r == '$' || unicode.IsLetter(r) || i > 0 && unicode.IsDigit(r) {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package json2go
+
+import "testing"
+
+func TestTransformToTypeScript(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		opts     Options
+		expected string
+		err      error
+	}{
+		"scalars": {
+			input: `{"id": 1, "price": 9.99, "ok": true, "name": "a", "my-key": "b"}`,
+			expected: "export interface Out {\n" +
+				"  id: number;\n" +
+				"  price: number;\n" +
+				"  ok: boolean;\n" +
+				"  name: string;\n" +
+				"  \"my-key\": string;\n" +
+				"}",
+		},
+		"optional and nullable fields": {
+			input: `[{"a": 1, "b": "x"}, {"a": null}]`,
+			expected: "export type Out = OutItem[];\n\n" +
+				"export interface OutItem {\n" +
+				"  a: number | null;\n" +
+				"  b?: string;\n" +
+				"}",
+		},
+		"nested interfaces": {
+			input: `{"user": {"name": "a", "address": {"city": "b"}}, "owner": {"name": "c", "address": {"city": "d"}}}`,
+			expected: "export interface Out {\n" +
+				"  user: OutUser;\n" +
+				"  owner: OutUser;\n" +
+				"}\n\n" +
+				"export interface OutUser {\n" +
+				"  name: string;\n" +
+				"  address: OutUserAddress;\n" +
+				"}\n\n" +
+				"export interface OutUserAddress {\n" +
+				"  city: string;\n" +
+				"}",
+		},
+		"mixed, empty and unknown values": {
+			input: `{"values": [1, "a", null], "meta": {}, "none": [], "nothing": null}`,
+			expected: "export interface Out {\n" +
+				"  values: (string | number | null)[];\n" +
+				"  meta: Record<string, unknown>;\n" +
+				"  none: unknown[];\n" +
+				"  nothing: null;\n" +
+				"}",
+		},
+		"maps": {
+			input: `{"1234": {"n": 1}, "5678": {"n": 2}}`,
+			opts:  Options{DetectMaps: true},
+			expected: "export type Out = Record<string, OutValue>;\n\n" +
+				"export interface OutValue {\n" +
+				"  n: number;\n" +
+				"}",
+		},
+		"unions": {
+			input: `{"events": [{"type": "click", "x": 1}, {"type": "view", "page": "/"}]}`,
+			opts:  Options{Unions: true},
+			expected: "export interface Out {\n" +
+				"  events: OutEventsItem[];\n" +
+				"}\n\n" +
+				"export type OutEventsItem = OutEventsItemClick | OutEventsItemView;\n\n" +
+				"export interface OutEventsItemClick {\n" +
+				"  type: \"click\";\n" +
+				"  x: number;\n" +
+				"}\n\n" +
+				"export interface OutEventsItemView {\n" +
+				"  type: \"view\";\n" +
+				"  page: string;\n" +
+				"}",
+		},
+		"invalid json": {
+			input: `{"invalid":json}`,
+			err:   ErrInvalidJSON,
+		},
+	}
+
+	for tname, tt := range tests {
+		t.Run(tname, func(t *testing.T) {
+			result, err := TransformToTypeScript("Out", tt.input, tt.opts)
+			assertEqualErr(t, tt.err, err)
+			if tt.err == nil && result != tt.expected {
+				t.Errorf("wrong output\nexpected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := TransformToTypeScript("my-type", `{}`, Options{})
+		assertEqualErr(t, ErrInvalidStructName, err)
+	})
+}
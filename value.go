@@ -18,8 +18,9 @@ type Value struct {
 	Kind ValueType
 
 	// only one of these is set depending on Kind
-	Str    string
+	Str    string // as written in the json source, escape sequences are kept
 	Int    int64
+	Raw    string // literal of a NumberValue that overflows int64, Int is then zero
 	Float  float64
 	Bool   bool
 	Object []Field // ordered, preserves key order